@@ -11,9 +11,35 @@ func IsPunctuation(token string) bool {
 	return Checking_Punctuation.MatchString(token)
 }
 
-// IsWord проверяет, является ли токен словом (не пунктуация и не заключён в круглые скобки).
+// IsQuote проверяет, является ли токен отдельной двойной кавычкой.
+func IsQuote(token string) bool {
+	return token == "\""
+}
+
+// IsDash проверяет, является ли токен отдельным дефисом или тире (-, --, –, —).
+func IsDash(token string) bool {
+	return DashCheck.MatchString(token)
+}
+
+// IsOpeningBracket проверяет, заканчивается ли токен открывающей скобкой: (, [, { или "( после склейки с кавычкой.
+func IsOpeningBracket(token string) bool {
+	return token != "" && strings.ContainsRune("([{", rune(token[len(token)-1]))
+}
+
+// IsClosingBracket проверяет, начинается ли токен с закрывающей скобки: ), ], } или )" после склейки с кавычкой.
+func IsClosingBracket(token string) bool {
+	return token != "" && strings.ContainsRune(")]}", rune(token[0]))
+}
+
+// IsBracket проверяет, является ли токен отдельной скобкой.
+func IsBracket(token string) bool {
+	return len(token) == 1 && strings.ContainsRune("()[]{}", rune(token[0]))
+}
+
+// IsWord проверяет, является ли токен словом (не пунктуация, не кавычка, не тире, не скобка и не тег в скобках).
 func IsWord(token string) bool {
-	return !IsPunctuation(token) && !strings.HasPrefix(token, "(") && !strings.HasSuffix(token, ")")
+	return !IsPunctuation(token) && !IsQuote(token) && !IsDash(token) && !IsBracket(token) &&
+		!strings.HasPrefix(token, "(") && !strings.HasSuffix(token, ")")
 }
 
 // IsHex проверяет, является ли строка допустимым шестнадцатеричным числом.
@@ -23,6 +49,18 @@ func IsHex(s string) bool {
 	return match
 }
 
+// IsInteger проверяет, является ли строка целым десятичным числом (возможно, со знаком минус).
+// Использует регулярное выражение IntegerCheck.
+func IsInteger(s string) bool {
+	return IntegerCheck.MatchString(s)
+}
+
+// IsRoman проверяет, является ли строка корректным непустым римским числом (I–MMMCMXCIX).
+// Использует регулярное выражение RomanCheck.
+func IsRoman(s string) bool {
+	return s != "" && RomanCheck.MatchString(s)
+}
+
 // IsBinary проверяет, является ли строка допустимым двоичным числом (содержит только 0 и 1).
 func IsBinary(s string) bool {
 	for _, c := range s {
@@ -3,12 +3,35 @@ package additional_functions
 import "regexp"
 
 var (
-	Checking_Punctuation = regexp.MustCompile(`^[.,!?;:]+$`)
+	Checking_Punctuation = regexp.MustCompile(`^[.,!?;:…]+$`)
 	IsHexCheck = "^[0-9a-fA-F]+$"
-	RegToken = regexp.MustCompile(`\([a-zA-Z]+(?:,\s*-?\d+)?\)|(\([^)]*\))|(\([^)]*)|[\w']+|[.,!?;:]+|\n`)
-	RemoveSpaceBeforePunct = regexp.MustCompile(`\s+([.,!?;:])`)
-	RemoveSpaceBetweenPuncts = regexp.MustCompile(`([.,!?;:])\s+([.,!?;:])`)
-	ApostropheContentWithSpaces = regexp.MustCompile(`'(\s*)([^']*?)(\s*)'`)
-	SplitAdjacentApostrophes = regexp.MustCompile(`'([^']*)''([^']*)'`)
+	DashCheck = regexp.MustCompile(`^(?:-{1,3}|–|—)$`)
+	IntegerCheck = regexp.MustCompile(`^-?\d+$`)
+	RomanCheck = regexp.MustCompile(`^M{0,3}(CM|CD|D?C{0,3})(XC|XL|L?X{0,3})(IX|IV|V?I{0,3})$`)
+	ProtectedPatterns = []string{
+		`(?:https?|ftp)://[^\s"'<>()]*[^\s"'<>().,!?;:]`,              // URL
+		`[\p{L}\p{N}._%+-]+@[\p{L}\p{N}-]+(?:\.[\p{L}\p{N}-]+)+`,           // e-mail
+		`\d{1,2}:\d{2}(?::\d{2})?\b`,                                   // время: 10:30, 23:59:59
+		`\d{1,3}(?:,\d{3})+(?:\.\d+)?(?:%|\b)`,                          // числа с разрядами: 1,000, 1,234.5
+		`\d+(?:\.\d+)+%?`,                                              // дробные числа и версии: 3.14, 1.2.3
+		`(?:\d{3}-){1,2}\d{4}\b`,                                      // телефоны: 555-1234, 555-123-4567
+		`\d+%`,                                                          // проценты
+		`(?:\p{L}\.){2,}`,                                               // сокращения: e.g., i.e., U.S.
+	}
+	TagPattern = `\(\s*[a-zA-Z]+\s*(?:,[^()\n]*)?\)` // запись тегов по умолчанию: (up, 2)
+	Directive = regexp.MustCompile(`(?m)^[ \t]*(?:\(reloaded:(on|off)([^)\n]*)\)|<!--[ \t]*reloaded:(on|off)([^\n]*?)-->)[ \t]*(?:\n|$)` +
+		`|\(reloaded:(on|off)([^)\n]*)\)|<!--[ \t]*reloaded:(on|off)([^\n]*?)-->`)
+	WordSpan = regexp.MustCompile(`[^\s—–]+`) // слова между пробелами и тире: a—apple → a, apple
+	RemoveSpaceBeforePunct = regexp.MustCompile(`\s+([.,!?;:…])`)
+	RemoveSpaceBetweenPuncts = regexp.MustCompile(`([.,!?;:…])\s+([.,!?;:…])`)
+	PunctuationRun = regexp.MustCompile(`[.,!?;:…‽]+`)
+	DotRun = regexp.MustCompile(`(?:\.|…){2,}|…`)
+	RangeToken = regexp.MustCompile(`^\d+-\d+$`)
+	ApostropheContentWithSpaces = regexp.MustCompile(`'([ \t]*)([^'\n]*?)([ \t]*)'`)
+	SplitAdjacentApostrophes = regexp.MustCompile(`'([^'\n]*)''([^'\n]*)'`)
+	DoubleQuoteContentWithSpaces = regexp.MustCompile(`"([ \t]*)([^"\n]*?)([ \t]*)"`)
+	PairedDoubleQuotes = regexp.MustCompile(`"([^"]*)"`)
+	PairedSingleQuotes = regexp.MustCompile(`'([^']*)'`)
+	SplitAdjacentDoubleQuotes = regexp.MustCompile(`"([^"\n]*)""([^"\n]*)"`)
 
 )
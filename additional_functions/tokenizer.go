@@ -0,0 +1,26 @@
+package additional_functions
+
+import (
+	"regexp"
+	"strings"
+)
+
+// BuildTokenRegexp собирает регулярное выражение токенизатора из шаблона тегов и защищённых шаблонов.
+// Теги и защищённые шаблоны (числа, время, адреса) проверяются раньше слов и пунктуации,
+// поэтому такие токены никогда не разбиваются.
+func BuildTokenRegexp(tag string, protected []string) *regexp.Regexp {
+	parts := []string{
+		tag,          // теги в выбранной записи: (up, 2), {{up, 2}}, @up(2)
+		`[()\[\]{}]`, // скобки
+	}
+	parts = append(parts, protected...)
+	parts = append(parts,
+		`"`,                                    // двойная кавычка
+		`[\p{L}\p{N}_']+(?:-[\p{L}\p{N}_']+)*`, // слова, в том числе через дефис
+		`-\d+(?:\.\d+)?%?`,                     // отрицательные числа
+		`-{1,3}|[–—]`,                          // дефисы и тире вне слов
+		`[.,!?;:…]+`,                           // пунктуация
+		`\n`,                                   // перенос строки
+	)
+	return regexp.MustCompile(strings.Join(parts, "|"))
+}
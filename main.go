@@ -1,20 +1,146 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
 	"go_reloaded/text_processing"
 )
 
+// readListFile читает файл со списком слов: по одному на строку,
+// пустые строки и строки, начинающиеся с #, пропускаются.
+func readListFile(path string) ([]string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []string
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		items = append(items, line)
+	}
+	return items, nil
+}
+
 func main() {
-	if len(os.Args) != 3 {
-		fmt.Println("Использование: go run main.go input.txt output.txt")
+	rulesFile := flag.String("rules", "", "файл правил замены: строки вида \"id: шаблон => замена | word, case\"")
+	glossaryFile := flag.String("glossary", "", "файл словаря особых написаний для (cap, smart)")
+	locale := flag.String("locale", "en", "язык оформления документа: en, ru, tr, de")
+	acronymStyle := flag.String("acronym-style", "letters", "чтение неоднозначных аббревиатур (SQL, URL): letters или word")
+	wordAcronymsFile := flag.String("word-acronyms", "", "файл аббревиатур, которые читаются как слова")
+	articlesFile := flag.String("articles", "", "файл исключений для артиклей: строки вида \"a herbal\" или \"an URL\"")
+	quotes := flag.String("quotes", "straight", "стиль кавычек в результате: straight, en, ru, de")
+	sentenceCaseFlag := flag.Bool("sentence-case", false, "делать заглавной первую букву предложений и местоимение i")
+	ellipsis := flag.String("ellipsis", "", "заменять серии точек на ... или … (по умолчанию не менять)")
+	maxRepeat := flag.Int("max-repeat", 0, "сколько одинаковых ! или ? подряд оставлять (0 — без ограничения)")
+	interrobang := flag.Bool("interrobang", false, "заменять ?! и !? на ‽")
+	noMixed := flag.Bool("no-mixed", false, "запрещать смешанные серии знаков вроде ,,;;")
+	tagSyntax := flag.String("tags", "parens", "запись тегов: parens — (up, 2), braces — {{up, 2}}, brackets — [[up, 2]] или at — @up(2)")
+	dashes := flag.String("dashes", "", "оформление тире между словами: em, em-spaced, en-spaced или off (по умолчанию — по языку документа)")
+	flag.Parse()
+
+	if flag.NArg() != 2 {
+		fmt.Println("Использование: go run main.go [флаги] input.txt output.txt")
+		flag.PrintDefaults()
+		os.Exit(1)
+	}
+
+	inputFile := flag.Arg(0)
+	outputFile := flag.Arg(1)
+
+	if err := text_processing.SetLocale(*locale); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	text_processing.SetSentenceCase(*sentenceCaseFlag)
+
+	err := text_processing.SetPunctuationPolicy(text_processing.PunctuationPolicy{
+		Ellipsis:    *ellipsis,
+		MaxRepeat:   *maxRepeat,
+		Interrobang: *interrobang,
+		NoMixed:     *noMixed,
+	})
+	if err != nil {
+		fmt.Println(err)
 		os.Exit(1)
 	}
 
-	inputFile := os.Args[1]
-	outputFile := os.Args[2]
+	if err := text_processing.SetTagSyntax(*tagSyntax); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if err := text_processing.SetDashStyle(*dashes); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if err := text_processing.SetQuoteStyle(*quotes); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if err := text_processing.SetAcronymStyle(*acronymStyle); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	// Загрузка аббревиатур, которые читаются как слова
+	if *wordAcronymsFile != "" {
+		acronyms, err := readListFile(*wordAcronymsFile)
+		if err != nil {
+			fmt.Printf("Ошибка при чтении списка аббревиатур %s: %v\n", *wordAcronymsFile, err)
+			os.Exit(1)
+		}
+		text_processing.AddWordAcronyms(acronyms)
+	}
+
+	// Загрузка исключений для артиклей
+	if *articlesFile != "" {
+		lines, err := readListFile(*articlesFile)
+		if err != nil {
+			fmt.Printf("Ошибка при чтении исключений %s: %v\n", *articlesFile, err)
+			os.Exit(1)
+		}
+		exceptions, err := text_processing.ParseArticleExceptions(lines)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		text_processing.SetArticleExceptions(exceptions)
+	}
+
+	// Загрузка правил замены
+	if *rulesFile != "" {
+		lines, err := readListFile(*rulesFile)
+		if err != nil {
+			fmt.Printf("Ошибка при чтении правил %s: %v\n", *rulesFile, err)
+			os.Exit(1)
+		}
+		rules, err := text_processing.ParseReplaceRules(lines)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		text_processing.SetReplaceRules(rules)
+	}
+
+	// Загрузка словаря особых написаний
+	if *glossaryFile != "" {
+		words, err := readListFile(*glossaryFile)
+		if err != nil {
+			fmt.Printf("Ошибка при чтении словаря %s: %v\n", *glossaryFile, err)
+			os.Exit(1)
+		}
+		text_processing.SetGlossary(words)
+	}
 
 	// Чтение входного файла
 	content, err := ioutil.ReadFile(inputFile)
@@ -33,5 +159,15 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Вывод замечаний, собранных при обработке
+	for _, d := range text_processing.Diagnostics() {
+		fmt.Fprintf(os.Stderr, "Предупреждение: %s\n", d)
+	}
+
+	// Вывод журнала замен по правилам
+	for _, c := range text_processing.Changes() {
+		fmt.Fprintf(os.Stderr, "Замена: %s\n", c)
+	}
+
 	fmt.Printf("Файл успешно обработан и сохранен в %s\n", outputFile)
 }
@@ -2,6 +2,7 @@ package main
 
 import (
 	"go_reloaded/text_processing"
+	"strings"
 	"testing"
 )
 
@@ -57,6 +58,131 @@ func TestProcess(t *testing.T) {
 			"Simply add 42 (hex) and 10 (bin)",
 			"Simply add 66 and 2",
 		},
+		{
+			"Roman numeral conversion",
+			"In 2024 (roman) we met",
+			"In MMXXIV we met",
+		},
+		{
+			"Arabic numeral conversion",
+			"Chapter XIV (arabic)",
+			"Chapter 14",
+		},
+		{
+			"Ordinal conversion with count",
+			"1 2 3 11 (ord, 4) place",
+			"1st 2nd 3rd 11th place",
+		},
+		{
+			"Invalid roman numeral remains unchanged",
+			"IIII (arabic) and 5000 (roman)",
+			"IIII and 5000",
+		},
+		{
+			"Thousands grouping",
+			"There are 1234567 (group) stars",
+			"There are 1,234,567 stars",
+		},
+		{
+			"Thousands grouping with russian locale",
+			"1234567 7654321 (group, 2, ru)",
+			"1 234 567 7 654 321",
+		},
+		{
+			"Hex conversion with grouping",
+			"18284829229392927abcd17283930 (hex, group)",
+			"7,839,508,634,210,545,570,538,414,416,607,536",
+		},
+		{
+			"Grouped number keeps its commas",
+			"It costs 1,234,567 dollars",
+			"It costs 1,234,567 dollars",
+		},
+		{
+			"Tag with unknown option is dropped",
+			"hello (up, loud) world",
+			"hello world",
+		},
+		{
+			"Smart capitalization follows the tag locale",
+			"istanbul (cap, smart, tr) and o'neil (cap, smart, tr)",
+			"İstanbul and O'Neil",
+		},
+		{
+			"Tag with two counts is dropped",
+			"hello big world (up, 2, 3)",
+			"hello big world",
+		},
+		{
+			"Option of another tag is dropped",
+			"hello world (up, 2, apa) and a big story (title, 2, ru)",
+			"hello world and a big story",
+		},
+		{
+			"Snake case merges words",
+			"the field user account id (snake, 3) is required",
+			"the field user_account_id is required",
+		},
+		{
+			"Apostrophes do not split merged words",
+			"don't stop (snake, 2) and we're done (camel, 2)",
+			"dont_stop and wereDone",
+		},
+		{
+			"Programming case styles",
+			"get user name (camel, 3) Http Server (pascal, 2) max retry count (kebab, 3) max size (constant, 2)",
+			"getUserName HttpServer max-retry-count MAX_SIZE",
+		},
+		{
+			"Merge stops at punctuation",
+			"hello, big world (snake, 3)",
+			"hello, big_world",
+		},
+		{
+			"Transformation applies to merged token",
+			"user account id (snake, 3) (up)",
+			"USER_ACCOUNT_ID",
+		},
+		{
+			"Title case keeps small words lowercase",
+			"it was the age of wisdom, 'it was the age of foolishness' (title, 6)",
+			"it was the age of wisdom, 'It Was the Age of Foolishness'",
+		},
+		{
+			"Title case capitalises last word and hyphenated parts",
+			"a tale of two cities (title, 5) and self-report measures (title, 2, apa)",
+			"A Tale of Two Cities and Self-Report Measures",
+		},
+		{
+			"Title case style guides differ on long prepositions",
+			"walking through the door (title, 4, ap) walking through the door (title, 4, chicago)",
+			"Walking Through the Door Walking through the Door",
+		},
+		{
+			"Title case crosses punctuation",
+			"war, peace and love (title, 4)",
+			"War, Peace and Love",
+		},
+		{
+			"Plain capitalisation lowercases the rest",
+			"NASA (cap) o'neil (cap)",
+			"Nasa O'neil",
+		},
+		{
+			"Smart capitalisation keeps acronyms and names",
+			"NASA o'neil jean-luc don't (cap, 4, smart)",
+			"NASA O'Neil Jean-Luc Don't",
+		},
+		{
+			"Turkish case mapping",
+			"istanbul ılık (up, 2, tr) İZMİR (low, tr) istanbul (cap, tr)",
+			"İSTANBUL ILIK izmir İstanbul",
+		},
+		{
+			"German sharp s uppercases to SS",
+			"straße (up, de)",
+			"STRASSE",
+		},
 		// Article Corrections
 		{
 			"Article correction before vowel in quotes",
@@ -232,6 +358,71 @@ func TestProcess(t *testing.T) {
 			"hi 'hi",
 			"hi 'hi",
 		},
+		{
+			"Double quotes spacing",
+			`He said " hello " to me`,
+			`He said "hello" to me`,
+		},
+		{
+			"Adjacent double quotes",
+			`"one""two"`,
+			`"one" "two"`,
+		},
+		{
+			"Double quotes nested in single quotes",
+			`' she said " hi " '`,
+			`'she said "hi"'`,
+		},
+		{
+			"Double quotes with punctuation inside",
+			`" wait , what ? "`,
+			`"wait, what?"`,
+		},
+		{
+			"Transformation skips double quotes",
+			`"quoted words" (up, 2)`,
+			`"QUOTED WORDS"`,
+		},
+		{
+			"Curly quotes are normalised",
+			"“ hello ” and ‘ world ’, don’t",
+			`"hello" and 'world', don't`,
+		},
+		{
+			"Plural possessives are not quotes",
+			"the students' books and the teachers' room",
+			"the students' books and the teachers' room",
+		},
+		{
+			"Elisions are not quotes",
+			"rock 'n' roll in the '90s, 'tis said ' loudly '",
+			"rock 'n' roll in the '90s, 'tis said 'loudly'",
+		},
+		{
+			"Dialect forms and contractions",
+			"we were goin' home, ' don't stop '",
+			"we were goin' home, 'don't stop'",
+		},
+		{
+			"Possessive inside quotes closes the quote",
+			"' the boss '",
+			"'the boss'",
+		},
+		{
+			"Protected numbers and times",
+			"Pi is 3.14 , we meet at 10:30 with 1,000 people .",
+			"Pi is 3.14, we meet at 10:30 with 1,000 people.",
+		},
+		{
+			"Protected abbreviations, URLs and e-mails",
+			"See e.g. https://example.com/a.b or write to john@example.com .",
+			"See e.g. https://example.com/a.b or write to john@example.com.",
+		},
+		{
+			"Tags treat protected tokens as single words",
+			"version 1.2.3 (up) and a 8.5 (cap) score",
+			"version 1.2.3 and an 8.5 score",
+		},
 
 		// Edge Cases
 		{
@@ -309,6 +500,66 @@ func TestProcess(t *testing.T) {
 			"ab (up) (HeX) (low)",
 			"171",
 		},
+		{
+			"Space outside parentheses",
+			"word(see above) and more",
+			"word (see above) and more",
+		},
+		{
+			"No space inside brackets",
+			"it is ( really ) good [ note 1 ] and { x }",
+			"it is (really) good [note 1] and {x}",
+		},
+		{
+			"Punctuation after closing bracket",
+			"see ( sic ) , then [ 2 ] .",
+			"see (sic), then [2].",
+		},
+		{
+			"Unknown tag-like text is prose",
+			"hello (sic) and (see, above)",
+			"hello (sic) and (see, above)",
+		},
+		{
+			"Words in brackets are counted by tags",
+			"my (big) dog (up, 2)",
+			"my (BIG) DOG",
+		},
+		{
+			"Quotes around brackets",
+			"\"( see )\" ok",
+			"\"(see)\" ok",
+		},
+		{
+			"Unclosed bracket",
+			"text (unclosed",
+			"text (unclosed",
+		},
+		{
+			"Region on its own lines is kept verbatim",
+			"it is a apple ,\n(reloaded:off)\nkeep  a apple (up) ,   as is\n(reloaded:on)\nthen a apple .",
+			"it is an apple,\nkeep  a apple (up) ,   as is\nthen an apple.",
+		},
+		{
+			"HTML comment form inside a line",
+			"before a apple <!-- reloaded:off --> raw  a apple ,<!-- reloaded:on --> after a apple",
+			"before an apple raw  a apple , after an apple",
+		},
+		{
+			"Only articles are disabled",
+			"(reloaded:off articles)a apple (up) , ok (reloaded:on articles) a apple",
+			"a APPLE, ok an apple",
+		},
+		{
+			"Several rules at once",
+			"<!-- reloaded:off tags, punctuation -->hello (up) , a apple",
+			"hello (up), an apple",
+		},
+		{
+			"Region until the end of text",
+			"a apple (reloaded:off) a apple",
+			"an apple a apple",
+		},
 	}
 
 	for _, tt := range tests {
@@ -321,3 +572,549 @@ func TestProcess(t *testing.T) {
 		})
 	}
 }
+
+func TestArticlePronunciation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		// Немое h
+		{"a hour", "an hour"},
+		{"a hourly rate", "an hourly rate"},
+		{"a hour-long meeting", "an hour-long meeting"},
+		{"a heir", "an heir"},
+		{"a heiress", "an heiress"},
+		{"a heirloom", "an heirloom"},
+		{"a honest man", "an honest man"},
+		{"a honorary degree", "an honorary degree"},
+		{"an house", "a house"},
+		{"an hotel", "a hotel"},
+		{"an historic day", "a historic day"},
+		{"an horse", "a horse"},
+		// o со звуком [w]
+		{"an one-time offer", "a one-time offer"},
+		{"an one", "a one"},
+		{"an once-in-a-lifetime chance", "a once-in-a-lifetime chance"},
+		{"a onion", "an onion"},
+		{"a orange", "an orange"},
+		// u со звуком [ju]
+		{"an user", "a user"},
+		{"an users' guide", "a users' guide"},
+		{"an useful tool", "a useful tool"},
+		{"an unicorn", "a unicorn"},
+		{"an union", "a union"},
+		{"an unique case", "a unique case"},
+		{"an university", "a university"},
+		{"an utensil", "a utensil"},
+		{"an usual day", "a usual day"},
+		{"an ukulele", "a ukulele"},
+		{"an unicycle", "a unicycle"},
+		{"a umbrella", "an umbrella"},
+		{"a unidentified object", "an unidentified object"},
+		{"a unimportant detail", "an unimportant detail"},
+		{"a untold story", "an untold story"},
+		{"a upper floor", "an upper floor"},
+		{"a usher", "an usher"},
+		{"a urgent call", "an urgent call"},
+		// eu и ewe
+		{"an european", "a european"},
+		{"an euro", "a euro"},
+		{"an eulogy", "a eulogy"},
+		{"an ewe", "a ewe"},
+		{"a egg", "an egg"},
+		// Аббревиатуры и отдельные буквы
+		{"a MBA", "an MBA"},
+		{"an FBI", "an FBI"},
+		{"a FBI agent", "an FBI agent"},
+		{"an UFO", "a UFO"},
+		{"a X", "an X"},
+		{"an u-turn", "a u-turn"},
+		{"A HOUR AGO", "AN HOUR AGO"},
+		{"an NASA probe", "a NASA probe"},
+		{"a OPEC meeting", "an OPEC meeting"},
+		{"an UNESCO site", "a UNESCO site"},
+		{"a UNESCO site", "a UNESCO site"},
+		{"an unesco site", "a unesco site"},
+		{"a SQL query", "an SQL query"},
+		{"a HTML page", "an HTML page"},
+		{"a NATO summit", "a NATO summit"},
+		// Числа
+		{"a 8-hour day", "an 8-hour day"},
+		{"a 11th hour", "an 11th hour"},
+		{"a 80-page report", "an 80-page report"},
+		{"a 11", "an 11"},
+		{"a 18th birthday", "an 18th birthday"},
+		{"a 18,000 crowd", "an 18,000 crowd"},
+		{"a 1800s house", "an 1800s house"},
+		{"an 1,800 crowd", "a 1,800 crowd"},
+		{"an 1,100 votes", "a 1,100 votes"},
+		{"an 1,000 people", "a 1,000 people"},
+		{"an 110 score", "a 110 score"},
+		{"an 5-star hotel", "a 5-star hotel"},
+		{"a 8% rise", "an 8% rise"},
+		{"an 100% chance", "a 100% chance"},
+		{"a 1011 (bin) year", "an 11 year"},
+		{"a 1f88 (hex, group) byte", "an 8,072 byte"},
+		{"an 3e8 (hex, group) byte", "a 1,000 byte"},
+		{"A HOUSE IS RED", "A HOUSE IS RED"},
+		{"AN EASY TASK", "AN EASY TASK"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if output := text_processing.ProcessText(tt.input); output != tt.expected {
+				t.Errorf("input: %q\n output %q\n wants: %q", tt.input, output, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAcronymStyle(t *testing.T) {
+	if err := text_processing.SetAcronymStyle(text_processing.AcronymWord); err != nil {
+		t.Fatal(err)
+	}
+	defer text_processing.SetAcronymStyle(text_processing.AcronymLetters)
+
+	input := "an SQL query, a URL and an FBI agent"
+	expected := "a SQL query, an URL and an FBI agent"
+	if output := text_processing.ProcessText(input); output != expected {
+		t.Errorf("input: %q\n output %q\n wants: %q", input, output, expected)
+	}
+
+	if err := text_processing.SetAcronymStyle("loud"); err == nil {
+		t.Error("expected error for unknown acronym style")
+	}
+}
+
+func TestCorrectArticlesLookahead(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`a "apple"`, `an "apple"`},
+		{"a (old) apple", "an (old) apple"},
+		{"an (parenthetical) apple", "a (parenthetical) apple"},
+		{"a - apple", "an - apple"},
+		{"a — honest man", "an — honest man"},
+		{"a *urgent* note", "an *urgent* note"},
+		{"a «apple»", "an «apple»"},
+		{"a\napple", "an\napple"},
+		{"A '", "A '"},
+		{"A ' \"", "A ' \""},
+		{"A 8", "An 8"},
+		{"a ' ' (", "a ' ' ("},
+		{"\tA  apple\n  a\torange\t", "\tAn  apple\n  an\torange\t"},
+		{"NEWLINE_MARKER a apple", "NEWLINE_MARKER an apple"},
+		{"  indented text\n\n\tan cat  ", "  indented text\n\n\ta cat  "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if output := text_processing.CorrectArticles(tt.input); output != tt.expected {
+				t.Errorf("input: %q\n output %q\n wants: %q", tt.input, output, tt.expected)
+			}
+		})
+	}
+}
+
+func TestArticleExceptions(t *testing.T) {
+	exceptions, err := text_processing.ParseArticleExceptions([]string{"a herb*", "an URL", "a Ukr*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	text_processing.SetArticleExceptions(exceptions)
+	defer text_processing.SetArticleExceptions(nil)
+
+	input := "an herbal tea, an herb, a URL, an Ukrainer and a hour"
+	expected := "a herbal tea, a herb, an URL, a Ukrainer and an hour"
+	if output := text_processing.ProcessText(input); output != expected {
+		t.Errorf("input: %q\n output %q\n wants: %q", input, output, expected)
+	}
+
+	for _, line := range []string{"the herbal", "a", "an *"} {
+		if _, err := text_processing.ParseArticleExceptions([]string{line}); err == nil {
+			t.Errorf("expected error for %q", line)
+		}
+	}
+}
+
+func TestQuoteStyle(t *testing.T) {
+	defer text_processing.SetQuoteStyle("straight")
+
+	tests := []struct {
+		style    string
+		input    string
+		expected string
+	}{
+		{"en", `He said "fine" and 'ok'`, "He said “fine” and ‘ok’"},
+		{"en", `"It's fine"`, "“It’s fine”"},
+		{"en", "rock'n'roll, don't", "rock’n’roll, don’t"},
+		{"en", "« curly » input", "“curly” input"},
+		{"en", "the students' 'best' books", "the students’ ‘best’ books"},
+		{"ru", `Он сказал " привет , 'друг' "`, "Он сказал «привет, „друг“»"},
+		{"de", `Er sagte "Hallo"`, "Er sagte „Hallo“"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.style+" "+tt.input, func(t *testing.T) {
+			if err := text_processing.SetQuoteStyle(tt.style); err != nil {
+				t.Fatal(err)
+			}
+			if output := text_processing.ProcessText(tt.input); output != tt.expected {
+				t.Errorf("input: %q\n output %q\n wants: %q", tt.input, output, tt.expected)
+			}
+		})
+	}
+
+	if err := text_processing.SetQuoteStyle("fr"); err == nil {
+		t.Error("expected error for unknown quote style")
+	}
+}
+
+func TestQuotePairingAcrossLines(t *testing.T) {
+	tests := []struct {
+		description string
+		input       string
+		expected    string
+		diagnostics int
+	}{
+		{"Quote spans a line break", "\" hello\nworld \"", "\"hello\nworld\"", 0},
+		{"Unclosed quote at paragraph end", "He said \" hi\n\nShe said \" bye \"", "He said \"hi\n\nShe said \"bye\"", 1},
+		{"Multi-paragraph quotation", "\" First part.\n\n\" Second part. \"", "\"First part.\n\n\"Second part.\"", 0},
+		{"Stray apostrophe does not shift later pairs", "a stray' mark\n\n' quoted '", "a stray' mark\n\n'quoted'", 1},
+		{"Nested quotes", "' he said \" go \" '", "'he said \"go\"'", 0},
+		{"Unclosed nested quote", "\" outer ' inner \"", "\"outer 'inner\"", 1},
+		{"Possessive inside a quote", "' the cats' toys '", "'the cats' toys'", 0},
+		{"Dropped g inside a quote", "' we were goin' home '", "'we were goin' home'", 0},
+		{"Possessive closes a quote", "' the students' and ' more '", "'the students' and 'more'", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			output := text_processing.ProcessText(tt.input)
+			if output != tt.expected {
+				t.Errorf("input: %q\n output %q\n wants: %q", tt.input, output, tt.expected)
+			}
+			if got := len(text_processing.Diagnostics()); got != tt.diagnostics {
+				t.Errorf("expected %d diagnostics, got %v", tt.diagnostics, text_processing.Diagnostics())
+			}
+		})
+	}
+}
+
+func TestAddProtectedPattern(t *testing.T) {
+	defer text_processing.ResetTokenizer()
+
+	if err := text_processing.AddProtectedPattern(`#\w+`); err != nil {
+		t.Fatal(err)
+	}
+
+	input := "tagged #golang , nice"
+	expected := "tagged #golang, nice"
+	if output := text_processing.ProcessText(input); output != expected {
+		t.Errorf("input: %q\n output %q\n wants: %q", input, output, expected)
+	}
+
+	if err := text_processing.AddProtectedPattern(`(`); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+}
+
+func TestSentences(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"Hello world. How are you? Fine!", []string{"Hello world.", "How are you?", "Fine!"}},
+		{"Mr. Smith met Dr. Jones. They talked.", []string{"Mr. Smith met Dr. Jones.", "They talked."}},
+		{"Use a tool, e.g. this one. Done.", []string{"Use a tool, e.g. this one.", "Done."}},
+		{"Pi is 3.14 exactly. Yes.", []string{"Pi is 3.14 exactly.", "Yes."}},
+		{"I was thinking... about it. Wait... Then what?", []string{"I was thinking... about it.", "Wait...", "Then what?"}},
+		{`"Stop!" he said. "Why?" She left.`, []string{`"Stop!" he said.`, `"Why?"`, "She left."}},
+		{"J. R. R. Tolkien wrote it. really?! yes", []string{"J. R. R. Tolkien wrote it.", "really?!", "yes"}},
+		{"First paragraph\n\nSecond one", []string{"First paragraph", "Second one"}},
+		{"He said no. She left.", []string{"He said no.", "She left."}},
+		{"See No. 5 and p. 12 in fig. 3. Done.", []string{"See No. 5 and p. 12 in fig. 3.", "Done."}},
+		{"Smith et al. wrote it. They said so.", []string{"Smith et al. wrote it.", "They said so."}},
+		{"The pass was at a high al. Then we rested.", []string{"The pass was at a high al.", "Then we rested."}},
+		{"", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			output := text_processing.Sentences(tt.input)
+			if strings.Join(output, "|") != strings.Join(tt.expected, "|") || len(output) != len(tt.expected) {
+				t.Errorf("input: %q\n output %q\n wants: %q", tt.input, output, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSentenceCase(t *testing.T) {
+	text_processing.SetSentenceCase(true)
+	defer text_processing.SetSentenceCase(false)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"hello world. how are you? i'm fine, and i think so .", "Hello world. How are you? I'm fine, and I think so."},
+		{"a apple. an orange", "An apple. An orange"},
+		{"hello (low) there. mr. smith arrived", "hello there. Mr. smith arrived"},
+		{"' wow! ' he said. then i.e. nothing", "'Wow!' he said. Then i.e. nothing"},
+		{"first line\n\nsecond paragraph", "First line\n\nSecond paragraph"},
+		{"john@example.com wrote. it is fine", "john@example.com wrote. It is fine"},
+		{"he said no. she left.", "He said no. She left."},
+		{"see https://example.com/i/page or mail i@example.com, i said", "See https://example.com/i/page or mail i@example.com, I said"},
+		{"he said 'i know' and (i agree) but x-i stays", "He said 'I know' and (I agree) but x-i stays"},
+		{"hello (reloaded:off tags) there and more", "Hello there and more"},
+		{"end. (reloaded:off) RAW (reloaded:on) and more. next", "End. RAW and more. Next"},
+		{"(reloaded:off)\nraw text.\n(reloaded:on)\nthen more", "raw text.\nThen more"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if output := text_processing.ProcessText(tt.input); output != tt.expected {
+				t.Errorf("input: %q\n output %q\n wants: %q", tt.input, output, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPunctuationPolicy(t *testing.T) {
+	defer text_processing.SetPunctuationPolicy(text_processing.PunctuationPolicy{})
+
+	tests := []struct {
+		description string
+		policy      text_processing.PunctuationPolicy
+		input       string
+		expected    string
+	}{
+		{"Ellipsis with three dots", text_processing.PunctuationPolicy{Ellipsis: "..."}, "I was thinking .  .    ........... .....", "I was thinking..."},
+		{"Ellipsis character", text_processing.PunctuationPolicy{Ellipsis: "…"}, "Wait .. what… ok...", "Wait… what… ok…"},
+		{"Limit repeated marks", text_processing.PunctuationPolicy{MaxRepeat: 1}, "BAMM !  !  !!!! Really ??", "BAMM! Really?"},
+		{"Interrobang", text_processing.PunctuationPolicy{Interrobang: true}, "What ?! No !?", "What‽ No‽"},
+		{"Mixed runs are forbidden", text_processing.PunctuationPolicy{NoMixed: true}, "tests are:::: ;;; ;; ????? ,,,,,, ok ?!", "tests are: ok?!"},
+		{"Abbreviation dot before a comma", text_processing.PunctuationPolicy{NoMixed: true}, "use a tool, e.g., this one, etc.; fine", "use a tool, e.g., this one, etc.; fine"},
+		{"Zero policy keeps punctuation", text_processing.PunctuationPolicy{}, "BAMM !  !  !!!!", "BAMM!!!!!!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			if err := text_processing.SetPunctuationPolicy(tt.policy); err != nil {
+				t.Fatal(err)
+			}
+			if output := text_processing.ProcessText(tt.input); output != tt.expected {
+				t.Errorf("input: %q\n output %q\n wants: %q", tt.input, output, tt.expected)
+			}
+		})
+	}
+
+	text_processing.SetPunctuationPolicy(text_processing.PunctuationPolicy{NoMixed: true})
+	text_processing.ProcessText("use a tool, e.g., this one")
+	if diagnostics := text_processing.Diagnostics(); len(diagnostics) != 0 {
+		t.Errorf("expected no diagnostics for e.g., got %v", diagnostics)
+	}
+
+	if err := text_processing.SetPunctuationPolicy(text_processing.PunctuationPolicy{Ellipsis: "--"}); err == nil {
+		t.Error("expected error for unknown ellipsis")
+	}
+}
+
+func TestDashes(t *testing.T) {
+	defer text_processing.SetDashStyle("")
+	defer text_processing.SetLocale("en")
+
+	tests := []struct {
+		description string
+		locale      string
+		style       string
+		input       string
+		expected    string
+	}{
+		{"Spaced hyphens become em dashes", "en", "", "I waited - and waited -- then left.", "I waited—and waited—then left."},
+		{"Number ranges use en dash", "en", "", "pages 10-20 and 10 - 20, not 2024-01-15", "pages 10–20 and 10–20, not 2024-01-15"},
+		{"Compound hyphens are kept", "en", "", "a well-known 8-hour day", "a well-known 8-hour day"},
+		{"Negative numbers are kept", "en", "", "it is -5 degrees", "it is -5 degrees"},
+		{"URLs are protected", "en", "", "see https://example.com/2020-2021/report - now", "see https://example.com/2020-2021/report—now"},
+		{"Phone numbers are protected", "en", "", "call 555-1234 or 555-123-4567", "call 555-1234 or 555-123-4567"},
+		{"Dash at line start is kept", "en", "", "- first item\n- second item", "- first item\n- second item"},
+		{"Existing dashes are restyled", "en", "em-spaced", "word—word and word – word", "word — word and word — word"},
+		{"British spaced en dash", "en", "en-spaced", "wait -- what", "wait – what"},
+		{"Russian style by locale", "ru", "", "Москва - столица", "Москва — столица"},
+		{"Dashes are not counted by tags", "en", "", "hello - world (up)", "hello—WORLD"},
+		{"Article before a dash", "en", "", "a - apple", "an—apple"},
+		{"Dash next to punctuation is kept", "en", "", "wait - , what", "wait -, what"},
+		{"Dash next to a quote is kept", "en", "", "hello - \"world\"", "hello - \"world\""},
+		{"Off keeps dashes as typed", "en", "off", "wait -- what", "wait -- what"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			if err := text_processing.SetLocale(tt.locale); err != nil {
+				t.Fatal(err)
+			}
+			if err := text_processing.SetDashStyle(tt.style); err != nil {
+				t.Fatal(err)
+			}
+			if output := text_processing.ProcessText(tt.input); output != tt.expected {
+				t.Errorf("input: %q\n output %q\n wants: %q", tt.input, output, tt.expected)
+			}
+		})
+	}
+
+	if err := text_processing.SetDashStyle("long"); err == nil {
+		t.Error("expected error for unknown dash style")
+	}
+}
+
+func TestTagSyntax(t *testing.T) {
+	defer text_processing.ResetTokenizer()
+
+	tests := []struct {
+		description string
+		syntax      string
+		input       string
+		expected    string
+	}{
+		{"Parentheses by default", "parens", "it is up to you (up, 2)", "it is up TO YOU"},
+		{"Double braces", "braces", "it is (up to you) and (low) hello world {{up,2}}", "it is (up to you) and (low) HELLO WORLD"},
+		{"Unknown braces tag is text", "braces", "keep {{foo}} here", "keep {{foo}} here"},
+		{"Double brackets with options", "brackets", "1E [[hex]] and 1234567 [[group, ru]]", "30 and 1 234 567"},
+		{"Sigil with count", "at", "say hi there @up(2) and bye @cap", "say HI THERE and Bye"},
+		{"Sigil does not break e-mail", "at", "mail me@example.com @up", "mail ME@EXAMPLE.COM"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			if err := text_processing.SetTagSyntax(tt.syntax); err != nil {
+				t.Fatal(err)
+			}
+			if output := text_processing.ProcessText(tt.input); output != tt.expected {
+				t.Errorf("input: %q\n output %q\n wants: %q", tt.input, output, tt.expected)
+			}
+		})
+	}
+
+	if err := text_processing.SetTagSyntax("angle"); err == nil {
+		t.Error("expected error for unknown tag syntax")
+	}
+}
+
+func TestReplaceRules(t *testing.T) {
+	rules, err := text_processing.ParseReplaceRules([]string{
+		"email: e-mail => email",
+		"go: Golang => Go | word",
+		"/utili[sz](e|ing)/ => us$1 | word, case",
+		"usd: USD => $US | word",
+		"up: up => above | word",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	text_processing.SetReplaceRules(rules)
+	defer text_processing.SetReplaceRules(nil)
+
+	tests := []struct {
+		description string
+		input       string
+		expected    string
+	}{
+		{"Literal replacement", "send an e-mail", "send an email"},
+		{"Dollar sign in literal replacement", "costs 5 USD", "costs 5 $US"},
+		{"Tag names are not rewritten", "speak up loud (up)", "speak above LOUD"},
+		{"Protected tokens are not rewritten", "see https://example.com/e-mail/help", "see https://example.com/e-mail/help"},
+		{"Whole word only", "Golang and Golangs", "Go and Golangs"},
+		{"Regex with case preservation", "Utilize it, UTILIZING and utilise", "Use it, USING and use"},
+		{"Replacement before tags and articles", "a e-mail (up)", "an EMAIL"},
+		{"Replacements can be disabled", "(reloaded:off replace)Golang e-mail", "Golang e-mail"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			if output := text_processing.ProcessText(tt.input); output != tt.expected {
+				t.Errorf("input: %q\n output %q\n wants: %q", tt.input, output, tt.expected)
+			}
+		})
+	}
+
+	text_processing.ProcessText("Golang e-mail")
+	changes := text_processing.Changes()
+	if len(changes) != 2 || changes[0].Rule != "go" || changes[0].Before != "Golang" || changes[1].Rule != "email" {
+		t.Errorf("unexpected change log: %v", changes)
+	}
+
+	for _, line := range []string{"no arrow here", "x => y | loud", "/(/ => y", "x: in order to => to", "/in\\sorder/ => y", "/a[ \t]b/ => y | case"} {
+		if _, err := text_processing.ParseReplaceRules([]string{line}); err == nil {
+			t.Errorf("expected error for rule %q", line)
+		}
+	}
+}
+
+func TestSmartCapitalizationGlossary(t *testing.T) {
+	text_processing.SetGlossary([]string{"iPhone", "McDonald"})
+	defer text_processing.SetGlossary(nil)
+
+	input := "my iphone (cap, smart) from mcdonald (cap, smart), i'll (cap, smart) say i'm (cap, smart) d'angelo (cap, smart)"
+	expected := "my iPhone from McDonald, I'll say I'm D'Angelo"
+	if output := text_processing.ProcessText(input); output != expected {
+		t.Errorf("input: %q\n output %q\n wants: %q", input, output, expected)
+	}
+}
+
+func TestDocumentLocale(t *testing.T) {
+	if err := text_processing.SetLocale("tr"); err != nil {
+		t.Fatal(err)
+	}
+	defer text_processing.SetLocale("en")
+
+	input := "kilim (up) 1234567 (group)"
+	expected := "KİLİM 1.234.567"
+	if output := text_processing.ProcessText(input); output != expected {
+		t.Errorf("input: %q\n output %q\n wants: %q", input, output, expected)
+	}
+
+	if err := text_processing.SetLocale("xx"); err == nil {
+		t.Error("expected error for unknown locale")
+	}
+}
+
+func TestDiagnostics(t *testing.T) {
+	text_processing.ProcessText("MMXXIV (arabic) IIII (arabic) 0 (roman)")
+	if got := len(text_processing.Diagnostics()); got != 2 {
+		t.Errorf("expected 2 diagnostics, got %d: %v", got, text_processing.Diagnostics())
+	}
+
+	text_processing.ProcessText(`he said "hi`)
+	if got := len(text_processing.Diagnostics()); got != 1 {
+		t.Errorf("expected unbalanced quote diagnostic, got %v", text_processing.Diagnostics())
+	}
+
+	text_processing.ProcessText("hello world (up, 2, 3)")
+	if got := len(text_processing.Diagnostics()); got != 1 {
+		t.Errorf("expected a diagnostic for repeated count, got %v", text_processing.Diagnostics())
+	}
+
+	text_processing.ProcessText("hello (up, apa) one two (snake, 2, group)")
+	if got := len(text_processing.Diagnostics()); got != 2 {
+		t.Errorf("expected options of other tags to be rejected, got %v", text_processing.Diagnostics())
+	}
+
+	// Объединяющий тег, прерванный другим тегом или кавычкой, сообщает о недостающих словах
+	for _, input := range []string{"one two (up) three (snake, 3)", `"user account" id (snake, 3)`, "short (title, 4)"} {
+		text_processing.ProcessText(input)
+		if got := len(text_processing.Diagnostics()); got != 1 {
+			t.Errorf("expected short group diagnostic for %q, got %v", input, text_processing.Diagnostics())
+		}
+	}
+
+	text_processing.ProcessText("(reloaded:off rhymes) x")
+	if got := len(text_processing.Diagnostics()); got != 1 {
+		t.Errorf("expected a diagnostic for unknown directive rule, got %v", text_processing.Diagnostics())
+	}
+
+	text_processing.ProcessText("14 (roman)")
+	if got := len(text_processing.Diagnostics()); got != 0 {
+		t.Errorf("expected diagnostics to be reset, got %v", text_processing.Diagnostics())
+	}
+}
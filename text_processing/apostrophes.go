@@ -0,0 +1,113 @@
+package text_processing
+
+import (
+	"strings"
+	"unicode"
+)
+
+// apostropheMask временно заменяет апострофы, которые не являются кавычками,
+// чтобы регулярные выражения для пар кавычек их не задевали
+const apostropheMask = '\uE000'
+
+// elisions — слова, в начале которых апостроф обозначает пропуск звуков: 'tis, 'em, 'cause
+var elisions = wordSet([]string{
+	"bout", "cause", "em", "ello", "kay", "n", "round", "til", "tis", "twas", "twere", "twill",
+})
+
+// isWordRune проверяет, является ли символ буквой или цифрой
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// runeAt возвращает символ по индексу или 0, если индекс вне строки
+func runeAt(runes []rune, i int) rune {
+	if i < 0 || i >= len(runes) {
+		return 0
+	}
+	return runes[i]
+}
+
+// isElision проверяет, начинается ли текст после апострофа с известного пропуска
+// ('tis, 'em, 'n') или с десятилетия ('90s)
+func isElision(rest []rune) bool {
+	end := 0
+	for end < len(rest) && isWordRune(rest[end]) {
+		end++
+	}
+	if end == 0 || (end < len(rest) && rest[end] == '\'' && string(rest[:end]) != "n") {
+		return false
+	}
+
+	word := strings.ToLower(string(rest[:end]))
+	if elisions[word] {
+		return true
+	}
+	// Десятилетия: '90s, '60s
+	return len(word) == 3 && unicode.IsDigit(rest[0]) && unicode.IsDigit(rest[1]) && word[2] == 's'
+}
+
+// maskApostrophes заменяет на apostropheMask апострофы, которые не являются кавычками:
+// сокращения (don't, rock'n'roll), пропуски в начале слова ('tis, '90s, 'n'),
+// притяжательные формы множественного числа (students') и диалектные формы (goin').
+// Кавычки отслеживаются слева направо: апостроф после s или in при открытой кавычке считается закрывающей,
+// только если дальше в абзаце не осталось непарной кавычки: ' the cats' toys '.
+func maskApostrophes(text string) string {
+	runes := []rune(text)
+	open := false
+	for i, r := range runes {
+		if r != '\'' {
+			continue
+		}
+		prev, next := runeAt(runes, i-1), runeAt(runes, i+1)
+
+		switch {
+		case isWordRune(prev) && isWordRune(next):
+			// Апостроф внутри слова: don't, o'clock
+			runes[i] = apostropheMask
+		case !isWordRune(prev) && isElision(runes[i+1:]):
+			// Пропуск в начале слова: 'tis, '90s, а для 'n' — и завершающий апостроф
+			runes[i] = apostropheMask
+			if next == 'n' && runeAt(runes, i+2) == '\'' {
+				runes[i+2] = apostropheMask
+			}
+		case isWordRune(prev) && (prev == 's' || (prev == 'n' && runeAt(runes, i-2) == 'i')) &&
+			(!open || quotesAfter(runes, i)%2 == 1):
+			// Притяжательная форма или диалектный пропуск: students', goin'
+			runes[i] = apostropheMask
+		default:
+			// Настоящая кавычка
+			open = !open
+		}
+	}
+	return string(runes)
+}
+
+// quotesAfter считает апострофы-кавычки после позиции i до конца абзаца,
+// пропуская апострофы внутри слов и пропуски в начале слова
+func quotesAfter(runes []rune, i int) int {
+	count := 0
+	for j := i + 1; j < len(runes); j++ {
+		if runes[j] == '\n' && runeAt(runes, j+1) == '\n' {
+			break
+		}
+		if runes[j] != '\'' {
+			continue
+		}
+		prev, next := runeAt(runes, j-1), runeAt(runes, j+1)
+		switch {
+		case isWordRune(prev) && isWordRune(next):
+		case !isWordRune(prev) && isElision(runes[j+1:]):
+			if next == 'n' && runeAt(runes, j+2) == '\'' {
+				j += 2
+			}
+		default:
+			count++
+		}
+	}
+	return count
+}
+
+// unmaskApostrophes возвращает замаскированные апострофы, заменяя их на replacement
+func unmaskApostrophes(text string, replacement string) string {
+	return strings.ReplaceAll(text, string(apostropheMask), replacement)
+}
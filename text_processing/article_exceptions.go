@@ -0,0 +1,62 @@
+package text_processing
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ArticleException задаёт артикль для слова в обход встроенных правил выбора a/an.
+type ArticleException struct {
+	Pattern string // слово или начало слова
+	Prefix  bool   // совпадение по началу слова, а не по слову целиком
+	An      bool   // true — всегда "an", false — всегда "a"
+}
+
+// articleExceptions — пользовательские исключения, проверяются до встроенных правил
+var articleExceptions []ArticleException
+
+// SetArticleExceptions задаёт пользовательские исключения для выбора артикля.
+// Вызов с nil убирает все исключения.
+func SetArticleExceptions(exceptions []ArticleException) {
+	articleExceptions = exceptions
+}
+
+// ParseArticleExceptions разбирает строки вида "a herbal", "an URL" или "a uni*":
+// первое слово — артикль, второе — слово, а * в конце означает совпадение по началу слова.
+func ParseArticleExceptions(lines []string) ([]ArticleException, error) {
+	var exceptions []ArticleException
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("некорректное исключение для артикля: %q", line)
+		}
+
+		article := strings.ToLower(fields[0])
+		if article != "a" && article != "an" {
+			return nil, fmt.Errorf("неизвестный артикль %q в исключении %q", fields[0], line)
+		}
+
+		pattern := fields[1]
+		prefix := strings.HasSuffix(pattern, "*")
+		pattern = strings.TrimSuffix(pattern, "*")
+		if pattern == "" {
+			return nil, fmt.Errorf("пустое слово в исключении %q", line)
+		}
+
+		exceptions = append(exceptions, ArticleException{Pattern: pattern, Prefix: prefix, An: article == "an"})
+	}
+	return exceptions, nil
+}
+
+// matchArticleException ищет пользовательское исключение для слова без учёта регистра.
+// Возвращает false во втором значении, если исключение не найдено.
+func matchArticleException(word string) (bool, bool) {
+	lower := strings.ToLower(word)
+	for _, exception := range articleExceptions {
+		pattern := strings.ToLower(exception.Pattern)
+		if lower == pattern || (exception.Prefix && strings.HasPrefix(lower, pattern)) {
+			return exception.An, true
+		}
+	}
+	return false, false
+}
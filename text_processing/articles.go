@@ -2,23 +2,23 @@ package text_processing
 
 import (
 	"go_reloaded/additional_functions"
-	"regexp"
 	"strings"
 	"unicode"
 )
 
 // CorrectArticles корректирует неопределённые артикли "a" и "an" в переданном тексте.
-// Учитываются начальные звуки следующих слов (гласные, "молчаливое h", и исключения).
+// Артикль выбирается по начальному звуку следующего слова: по встроенному словарю произношений,
+// а для слов вне словаря — по правилам чтения.
 func CorrectArticles(text string) string {
-	// Заменяем переносы строк на специальный маркер, чтобы сохранить структуру
-	text = strings.ReplaceAll(text, "\n", "NEWLINE_MARKER")
-	// Добавляем пробелы вокруг маркера, чтобы избежать склеивания с соседними словами
-	text = strings.ReplaceAll(text, "NEWLINE_MARKER", " NEWLINE_MARKER ")
-	words := strings.Fields(text) // Разбиваем текст на слова
-
-	if len(words) == 0 {
-		// Если текст пустой, возвращаем его с восстановленными переносами
-		return strings.ReplaceAll(text, "NEWLINE_MARKER", "\n")
+	// Находим границы слов, чтобы заменять только сами артикли, не трогая пробелы, тире и переносы строк
+	spans := additional_functions.WordSpan.FindAllStringIndex(text, -1)
+	if len(spans) == 0 {
+		return text
+	}
+
+	words := make([]string, len(spans)) // Слова текста
+	for i, span := range spans {
+		words[i] = text[span[0]:span[1]]
 	}
 
 	result := make([]string, len(words)) // Слайс для результата
@@ -34,13 +34,9 @@ func CorrectArticles(text string) string {
 			continue
 		}
 
-		// Если это последнее слово — не с чем сравнивать, пропускаем
-		if i+1 >= len(words) {
-			continue
-		}
-
-		nextWord := words[i+1]
-		if len(nextWord) == 0 {
+		// Ищем следующее настоящее слово; если его нет — не с чем сравнивать, пропускаем
+		nextWord, next := nextRealWord(words, i+1)
+		if next < 0 {
 			continue
 		}
 
@@ -53,50 +49,25 @@ func CorrectArticles(text string) string {
 			continue
 		}
 
-		shouldBeAn := false // Флаг, нужен ли артикль "an"
-		runes := []rune(strings.ToLower(nextWord))
-
-		// Определяем первую значимую букву (игнорируя знаки препинания)
-		firstChar := '0'
-		if !unicode.IsLetter(runes[0]) {
-			firstChar = runes[1]
-		} else {
-			firstChar = runes[0]
+		// Аббревиатуры читаются по буквам (an FBI, an MBA), если текст вокруг не набран целиком заглавными
+		isUpperWord := func(j int) bool {
+			return j >= 0 && j < len(words) && isAcronym(words[j])
 		}
+		shouting := word == "AN" || isUpperWord(i-1) || isUpperWord(next+1)
+		spelled := isAcronym(nextWord) && !shouting
 
-		// Обработка исключений с "молчаливым h"
-		if firstChar == 'h' {
-			silentHWords := []string{
-				"hour", "honor", "honour", "honest", "heir", "herb",
-			}
-			cleanNextWord := strings.Trim(lowerNextWord, ".,!?;:'")
-			silentHWordsFlag := false
-			for _, silentHWord := range silentHWords {
-				if strings.HasPrefix(cleanNextWord, silentHWord) {
-					silentHWordsFlag = true
-					break
-				}
-			}
-			if silentHWordsFlag {
-				shouldBeAn = true
-			}
-		} else if strings.ContainsRune("aeiou", firstChar) {
-			// Если слово начинается с гласной
-			shouldBeAn = true
-		}
+		// Выбор артикля по произношению следующего слова
+		shouldBeAn := startsWithVowelSound(nextWord, spelled)
 
-		// Исключения для "u" — например, "university", "european"
-		if firstChar == 'u' {
-			if strings.HasPrefix(lowerNextWord, "uni") || strings.HasPrefix(lowerNextWord, "eu") {
-				shouldBeAn = false
-			}
-		}
+		// Следующее слово набрано заглавными (хотя бы первые две буквы)
+		nextRunes := []rune(nextWord)
+		nextIsUpper := len(nextRunes) > 1 && !unicode.IsLower(nextRunes[0]) && !unicode.IsLower(nextRunes[1])
 
 		// Корректируем артикль в зависимости от анализа
 		if shouldBeAn {
 			if word == "a" {
 				result[i] = "an"
-			} else if word == "A" && !unicode.IsLower(rune(nextWord[0])) && !unicode.IsLower(rune(nextWord[1])) {
+			} else if word == "A" && nextIsUpper {
 				result[i] = "AN"
 			} else if word == "A" {
 				result[i] = "An"
@@ -110,12 +81,32 @@ func CorrectArticles(text string) string {
 		}
 	}
 
-	// Восстанавливаем структуру текста
-	out := strings.Join(result, " ")
-	out = strings.ReplaceAll(out, "NEWLINE_MARKER", "\n")
-	// Удаляем лишние пробелы перед и после перевода строки
-	out = regexp.MustCompile(`[ \t]+\n`).ReplaceAllString(out, "\n")
-	out = regexp.MustCompile(`\n[ \t]+`).ReplaceAllString(out, "\n")
+	// Собираем текст заново: между словами сохраняется исходное оформление
+	var sb strings.Builder
+	last := 0
+	for i, span := range spans {
+		sb.WriteString(text[last:span[0]])
+		sb.WriteString(result[i])
+		last = span[1]
+	}
+	sb.WriteString(text[last:])
 
-	return out
+	return sb.String()
+}
+
+// articleLookaheadSkip — открывающие кавычки, скобки, тире и символы выделения,
+// которые не влияют на выбор артикля: a "apple", a (big) apple, a *apple*
+const articleLookaheadSkip = "'\"([{«„“‘-–—*_~"
+
+// nextRealWord ищет первое настоящее слово, начиная с позиции start: пропускает токены,
+// состоящие только из кавычек, скобок и тире, а у найденного слова отбрасывает
+// ведущие такие символы. Возвращает слово и его позицию или -1, если слова нет.
+func nextRealWord(words []string, start int) (string, int) {
+	for j := start; j < len(words); j++ {
+		word := strings.TrimLeft(words[j], articleLookaheadSkip)
+		if word != "" {
+			return word, j
+		}
+	}
+	return "", -1
 }
@@ -0,0 +1,72 @@
+package text_processing
+
+import (
+	"strings"
+	"unicode"
+)
+
+// glossary — пользовательский словарь особых написаний (iPhone, McDonald),
+// ключ — слово в нижнем регистре
+var glossary = map[string]string{}
+
+// SetGlossary задаёт словарь особых написаний для умной капитализации (cap, smart).
+// Каждое слово сохраняется в том виде, в котором передано.
+func SetGlossary(words []string) {
+	glossary = map[string]string{}
+	for _, word := range words {
+		word = strings.TrimSpace(word)
+		if word != "" {
+			glossary[strings.ToLower(word)] = word
+		}
+	}
+}
+
+// smartCapitalize капитализирует слово с учётом аббревиатур и имён:
+// NASA остаётся NASA, o'neil → O'Neil, jean-luc → Jean-Luc, а слова из словаря
+// получают написание из словаря. Регистр меняется по правилам языка rules: (cap, smart, tr).
+// Ведущие и завершающие кавычки и знаки сохраняются.
+func smartCapitalize(s string, rules localeRules) string {
+	isLetterOrDigit := func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }
+	start := strings.IndexFunc(s, isLetterOrDigit)
+	if start < 0 {
+		return s
+	}
+	end := strings.LastIndexFunc(s, isLetterOrDigit) + 1
+	prefix, core, suffix := s[:start], s[start:end], s[end:]
+
+	if spelling, ok := glossary[strings.ToLower(core)]; ok {
+		return prefix + spelling + suffix
+	}
+	if isAcronym(core) {
+		return s
+	}
+
+	parts := strings.Split(core, "-")
+	for i, part := range parts {
+		if spelling, ok := glossary[strings.ToLower(part)]; ok {
+			parts[i] = spelling
+			continue
+		}
+		runes := []rune(localeCapitalize(part, rules))
+		// Приставка имени с апострофом: O'Neil, D'Angelo, L'Oréal; сокращения вроде I'll и y'all не меняются
+		if len(runes) > 2 && runes[1] == '\'' && strings.ContainsRune("ODL", runes[0]) {
+			runes[2] = []rune(rules.upper(string(runes[2])))[0]
+		}
+		parts[i] = string(runes)
+	}
+	return prefix + strings.Join(parts, "-") + suffix
+}
+
+// isAcronym проверяет, состоит ли слово из двух и более заглавных букв (цифры допускаются)
+func isAcronym(s string) bool {
+	letters := 0
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			if !unicode.IsUpper(r) {
+				return false
+			}
+			letters++
+		}
+	}
+	return letters >= 2
+}
@@ -0,0 +1,66 @@
+package text_processing
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Стили именования, принятые в программировании: объединяют слова в один токен,
+// например "user account id" → "user_account_id" (snake)
+
+// snakeCase: user_account_id
+func snakeCase(words []string) string {
+	return joinWords(words, "_", strings.ToLower, strings.ToLower)
+}
+
+// kebabCase: user-account-id
+func kebabCase(words []string) string {
+	return joinWords(words, "-", strings.ToLower, strings.ToLower)
+}
+
+// constantCase: USER_ACCOUNT_ID
+func constantCase(words []string) string {
+	return joinWords(words, "_", strings.ToUpper, strings.ToUpper)
+}
+
+// camelCase: userAccountId
+func camelCase(words []string) string {
+	return joinWords(words, "", strings.ToLower, capitalizeWord)
+}
+
+// pascalCase: UserAccountId
+func pascalCase(words []string) string {
+	return joinWords(words, "", capitalizeWord, capitalizeWord)
+}
+
+// removeApostrophes убирает апострофы внутри слов, чтобы don't не распадалось на части: dont
+var removeApostrophes = strings.NewReplacer("'", "", "’", "")
+
+// joinWords разбивает слова на буквенно-цифровые части, приводит первую часть к регистру first,
+// остальные — к регистру rest, и соединяет их разделителем separator. Апострофы не разделяют слово.
+func joinWords(words []string, separator string, first, rest func(string) string) string {
+	parts := []string{}
+	for _, word := range words {
+		parts = append(parts, strings.FieldsFunc(removeApostrophes.Replace(word), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})...)
+	}
+
+	for i, part := range parts {
+		if i == 0 {
+			parts[i] = first(part)
+		} else {
+			parts[i] = rest(part)
+		}
+	}
+	return strings.Join(parts, separator)
+}
+
+// capitalizeWord делает первую букву слова заглавной, а остальные — строчными
+func capitalizeWord(s string) string {
+	runes := []rune(strings.ToLower(s))
+	if len(runes) > 0 {
+		runes[0] = unicode.ToUpper(runes[0])
+	}
+	return string(runes)
+}
@@ -0,0 +1,34 @@
+package text_processing
+
+import "fmt"
+
+// Change описывает замену, сделанную правилом из файла правил:
+// идентификатор правила, исходный и новый текст.
+type Change struct {
+	Rule   string
+	Before string
+	After  string
+}
+
+// String возвращает замену в читаемом виде.
+func (c Change) String() string {
+	return fmt.Sprintf("[%s] %q → %q", c.Rule, c.Before, c.After)
+}
+
+// changes накапливает замены за последний вызов ProcessText.
+var changes []Change
+
+// addChange добавляет замену в журнал изменений.
+func addChange(rule, before, after string) {
+	changes = append(changes, Change{Rule: rule, Before: before, After: after})
+}
+
+// resetChanges очищает журнал изменений перед новой обработкой.
+func resetChanges() {
+	changes = nil
+}
+
+// Changes возвращает замены, сделанные правилами при последнем вызове ProcessText.
+func Changes() []Change {
+	return changes
+}
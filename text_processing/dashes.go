@@ -0,0 +1,109 @@
+package text_processing
+
+import (
+	"fmt"
+	"go_reloaded/additional_functions"
+	"strings"
+)
+
+// dashFormat описывает оформление тире между словами: знак и пробелы вокруг него
+type dashFormat struct {
+	dash   string
+	spaced bool
+}
+
+// dashStyles — оформление тире между словами
+var dashStyles = map[string]dashFormat{
+	"em":        {dash: "—"},               // без пробелов, как в американских руководствах: word—word
+	"em-spaced": {dash: "—", spaced: true}, // с пробелами, как в русской типографике: слово — слово
+	"en-spaced": {dash: "–", spaced: true}, // короткое тире с пробелами, как в британских руководствах: word – word
+}
+
+// dashStyle — выбранное оформление тире; пустая строка — по языку документа, "off" — не менять
+var dashStyle = ""
+
+// SetDashStyle задаёт оформление тире между словами: "em", "em-spaced", "en-spaced",
+// "off" (не менять) или "" (по языку документа: для ru — "em-spaced", иначе — "em").
+func SetDashStyle(style string) error {
+	style = strings.ToLower(style)
+	if _, ok := dashStyles[style]; !ok && style != "" && style != "off" {
+		return fmt.Errorf("неизвестное оформление тире: %s", style)
+	}
+	dashStyle = style
+	return nil
+}
+
+// isRangeDash проверяет, может ли отдельный знак соединять диапазон чисел: 10 - 20, 10 -- 20, 10 – 20
+func isRangeDash(token string) bool {
+	return token == "-" || token == "--" || token == "–"
+}
+
+// normalizeDashes различает дефисы и тире в потоке токенов: дефис внутри слова (well-known, 8-hour)
+// не меняется, диапазон чисел получает короткое тире без пробелов (10-20 → 10–20), а отдельно стоящие
+// -, --, – и — между словами становятся тире в выбранном оформлении. Защищённые токены (адреса,
+// телефоны вроде 555-1234), тире в начале или конце строки и тире рядом со знаками препинания,
+// кавычками и скобками не меняются.
+func normalizeDashes(tokens []string) []string {
+	style := dashStyle
+	if style == "off" {
+		return tokens
+	}
+	if style == "" {
+		style = "em"
+		if documentLocale == "ru" {
+			style = "em-spaced"
+		}
+	}
+	format := dashStyles[style]
+
+	// inLine проверяет, что токен по индексу есть и не является переносом строки
+	inLine := func(i int) bool {
+		return i >= 0 && i < len(tokens) && tokens[i] != "\n"
+	}
+
+	result := []string{}
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+
+		// Диапазон, который токенизатор увидел одним токеном: 10-20
+		if additional_functions.RangeToken.MatchString(token) && !protectedToken.MatchString(token) {
+			result = append(result, strings.Replace(token, "-", "–", 1))
+			continue
+		}
+
+		if !additional_functions.IsDash(token) || !inLine(i-1) || !inLine(i+1) || len(result) == 0 ||
+			!touchesWord(tokens[i-1], true) || !touchesWord(tokens[i+1], false) {
+			result = append(result, token)
+			continue
+		}
+
+		// Диапазон с отдельным знаком: 10 - 20
+		if isRangeDash(token) && additional_functions.IsInteger(tokens[i-1]) && additional_functions.IsInteger(tokens[i+1]) {
+			result[len(result)-1] += "–" + tokens[i+1]
+			i++
+			continue
+		}
+
+		// Тире между словами: с пробелами — отдельный токен, без пробелов — склеивается с соседями
+		if format.spaced {
+			result = append(result, format.dash)
+			continue
+		}
+		result[len(result)-1] += format.dash + tokens[i+1]
+		i++
+	}
+	return result
+}
+
+// touchesWord проверяет, что токен — слово, которое касается тире буквой или цифрой:
+// последней, если слово стоит перед тире, и первой, если после
+func touchesWord(token string, beforeDash bool) bool {
+	if !additional_functions.IsWord(token) {
+		return false
+	}
+	runes := []rune(token)
+	if beforeDash {
+		return isWordRune(runes[len(runes)-1])
+	}
+	return isWordRune(runes[0])
+}
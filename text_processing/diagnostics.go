@@ -0,0 +1,34 @@
+package text_processing
+
+import "fmt"
+
+// Diagnostic описывает замечание, возникшее при обработке текста:
+// этап, на котором оно появилось, исходный токен и текст сообщения.
+type Diagnostic struct {
+	Stage   string
+	Token   string
+	Message string
+}
+
+// String возвращает диагностику в читаемом виде.
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("[%s] %q: %s", d.Stage, d.Token, d.Message)
+}
+
+// diagnostics накапливает замечания за последний вызов ProcessText.
+var diagnostics []Diagnostic
+
+// addDiagnostic добавляет замечание в список диагностик.
+func addDiagnostic(stage, token, message string) {
+	diagnostics = append(diagnostics, Diagnostic{Stage: stage, Token: token, Message: message})
+}
+
+// resetDiagnostics очищает список диагностик перед новой обработкой.
+func resetDiagnostics() {
+	diagnostics = nil
+}
+
+// Diagnostics возвращает замечания, собранные при последнем вызове ProcessText.
+func Diagnostics() []Diagnostic {
+	return diagnostics
+}
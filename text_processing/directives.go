@@ -0,0 +1,88 @@
+package text_processing
+
+import (
+	"go_reloaded/additional_functions"
+	"strings"
+)
+
+// directiveRules — этапы, которые можно отключить директивой: (reloaded:off articles)
+var directiveRules = wordSet([]string{"tags", "punctuation", "dashes", "quotes", "apostrophes", "articles", "case", "replace"})
+
+// region — часть текста между директивами и отключённые в ней этапы; nil — обработка целиком отключена
+type region struct {
+	text     string
+	disabled map[string]bool
+}
+
+// splitRegions делит текст на части по директивам (reloaded:off) … (reloaded:on) и <!-- reloaded:off --> …
+// <!-- reloaded:on -->. Директива без списка этапов отключает или включает всю обработку,
+// со списком — только перечисленные этапы: (reloaded:off articles, quotes). Сами директивы удаляются,
+// а директива на отдельной строке удаляется вместе с переносом строки.
+func splitRegions(text string) []region {
+	regions := []region{}
+	disabled := map[string]bool{}
+	all := false
+	last := 0
+
+	// current возвращает настройки для текущей части текста
+	current := func() map[string]bool {
+		if all {
+			return nil
+		}
+		copied := make(map[string]bool, len(disabled))
+		for rule := range disabled {
+			copied[rule] = true
+		}
+		return copied
+	}
+
+	for _, m := range additional_functions.Directive.FindAllStringSubmatchIndex(text, -1) {
+		if m[0] > last {
+			regions = append(regions, region{text: text[last:m[0]], disabled: current()})
+		}
+		last = m[1]
+		// Директива между пробелами внутри строки: пробелы после неё лишние
+		if m[0] > 0 && (text[m[0]-1] == ' ' || text[m[0]-1] == '\t') {
+			for last < len(text) && (text[last] == ' ' || text[last] == '\t') {
+				last++
+			}
+		}
+
+		// Подгруппы: 1–2 — запись в скобках на отдельной строке, 3–4 — в комментарии на отдельной строке,
+		// 5–6 и 7–8 — те же записи внутри строки
+		state, rules := "", ""
+		for g := 1; g < len(m)/2; g += 2 {
+			if m[2*g] >= 0 {
+				state = text[m[2*g]:m[2*g+1]]
+				if m[2*g+2] >= 0 {
+					rules = text[m[2*g+2]:m[2*g+3]]
+				}
+				break
+			}
+		}
+		directive := strings.TrimSpace(text[m[0]:m[1]])
+
+		if strings.TrimSpace(rules) == "" {
+			all = state == "off"
+			disabled = map[string]bool{}
+			continue
+		}
+		for _, rule := range strings.Split(rules, ",") {
+			rule = strings.ToLower(strings.TrimSpace(rule))
+			if !directiveRules[rule] {
+				addDiagnostic("directives", directive, "неизвестный этап: "+rule)
+				continue
+			}
+			if state == "off" {
+				disabled[rule] = true
+			} else {
+				delete(disabled, rule)
+			}
+		}
+	}
+
+	if last < len(text) {
+		regions = append(regions, region{text: text[last:], disabled: current()})
+	}
+	return regions
+}
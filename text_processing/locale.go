@@ -0,0 +1,94 @@
+package text_processing
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// defaultLocale — язык оформления, используемый, если в теге он не указан
+const defaultLocale = "en"
+
+// documentLocale — язык оформления всего документа, задаётся через SetLocale
+var documentLocale = defaultLocale
+
+// localeRules описывает правила языка: разделитель разрядов и преобразование регистра
+type localeRules struct {
+	thousands string
+	upper     func(string) string
+	lower     func(string) string
+}
+
+// locales — поддерживаемые языки оформления
+var locales = map[string]localeRules{
+	"en": {thousands: ",", upper: strings.ToUpper, lower: strings.ToLower},
+	"ru": {thousands: " ", upper: strings.ToUpper, lower: strings.ToLower},
+	// В турецком i ↔ İ и ı ↔ I
+	"tr": {
+		thousands: ".",
+		upper:     func(s string) string { return strings.ToUpperSpecial(unicode.TurkishCase, s) },
+		lower:     func(s string) string { return strings.ToLowerSpecial(unicode.TurkishCase, s) },
+	},
+	// В немецком ß в верхнем регистре становится SS
+	"de": {
+		thousands: ".",
+		upper:     func(s string) string { return strings.ReplaceAll(strings.ToUpper(s), "ß", "SS") },
+		lower:     strings.ToLower,
+	},
+}
+
+// isKnownLocale проверяет, поддерживается ли указанный язык оформления
+func isKnownLocale(locale string) bool {
+	_, ok := locales[locale]
+	return ok
+}
+
+// SetLocale задаёт язык оформления документа, который используется тегами без явного языка.
+func SetLocale(locale string) error {
+	locale = strings.ToLower(locale)
+	if !isKnownLocale(locale) {
+		return fmt.Errorf("неизвестный язык оформления: %s", locale)
+	}
+	documentLocale = locale
+	return nil
+}
+
+// rulesFor возвращает правила указанного языка или языка документа, если язык не указан
+func rulesFor(locale string) localeRules {
+	if rules, ok := locales[locale]; ok {
+		return rules
+	}
+	return locales[documentLocale]
+}
+
+// groupDigits разбивает целое число на группы по три цифры с разделителем,
+// принятым в указанном языке: 1234567 → 1,234,567 (en) или 1 234 567 (ru).
+func groupDigits(s string, locale string) string {
+	separator := rulesFor(locale).thousands
+
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+
+	var sb strings.Builder
+	for i, c := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			sb.WriteString(separator)
+		}
+		sb.WriteRune(c)
+	}
+	return sign + sb.String()
+}
+
+// localeCapitalize делает первую букву слова заглавной, а остальные — строчными,
+// по правилам указанного языка. Ведущие кавычки и знаки сохраняются.
+func localeCapitalize(s string, rules localeRules) string {
+	start := strings.IndexFunc(s, unicode.IsLetter)
+	if start < 0 {
+		return rules.lower(s)
+	}
+	r := []rune(s[start:])[0]
+	rest := s[start+len(string(r)):]
+	return s[:start] + rules.upper(string(r)) + rules.lower(rest)
+}
@@ -0,0 +1,85 @@
+package text_processing
+
+import (
+	"go_reloaded/additional_functions"
+	"strconv"
+	"strings"
+)
+
+// romanNumerals — значения римских цифр в порядке убывания, включая вычитательные пары.
+var romanNumerals = []struct {
+	value  int
+	symbol string
+}{
+	{1000, "M"}, {900, "CM"}, {500, "D"}, {400, "CD"},
+	{100, "C"}, {90, "XC"}, {50, "L"}, {40, "XL"},
+	{10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"},
+}
+
+// toRoman переводит десятичное число в римскую запись (допустимый диапазон 1–3999).
+// Некорректный ввод остаётся без изменений и попадает в диагностику.
+func toRoman(s string) string {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		addDiagnostic("roman", s, "не является целым числом")
+		return s
+	}
+	if n < 1 || n > 3999 {
+		addDiagnostic("roman", s, "число вне диапазона 1–3999")
+		return s
+	}
+
+	var sb strings.Builder
+	for _, r := range romanNumerals {
+		for n >= r.value {
+			sb.WriteString(r.symbol)
+			n -= r.value
+		}
+	}
+	return sb.String()
+}
+
+// fromRoman переводит римское число в десятичное.
+// Некорректная римская запись остаётся без изменений и попадает в диагностику.
+func fromRoman(s string) string {
+	upper := strings.ToUpper(s)
+	if !additional_functions.IsRoman(upper) {
+		addDiagnostic("arabic", s, "некорректное римское число")
+		return s
+	}
+
+	n := 0
+	for i := 0; i < len(upper); {
+		for _, r := range romanNumerals {
+			if strings.HasPrefix(upper[i:], r.symbol) {
+				n += r.value
+				i += len(r.symbol)
+				break
+			}
+		}
+	}
+	return strconv.Itoa(n)
+}
+
+// toOrdinal добавляет к числу английский порядковый суффикс: 1st, 2nd, 3rd, 11th.
+// Некорректный ввод остаётся без изменений и попадает в диагностику.
+func toOrdinal(s string) string {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		addDiagnostic("ord", s, "не является неотрицательным целым числом")
+		return s
+	}
+
+	suffix := "th"
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return s + suffix
+}
@@ -28,8 +28,9 @@ func joinTokens(tokens []string) string {
 			continue
 		}
 
-		// Если это пунктуация, пишем без пробела перед ней
-		if additional_functions.IsPunctuation(token) {
+		// Пунктуация, закрывающая скобка и токен сразу после открывающей скобки — без пробела
+		if additional_functions.IsPunctuation(token) || additional_functions.IsClosingBracket(token) ||
+			additional_functions.IsOpeningBracket(tokens[i-1]) {
 			buf.WriteString(token)
 			continue
 		}
@@ -50,26 +51,97 @@ func joinTokens(tokens []string) string {
 
 // tokenize разбивает текст на токены, включая знаки препинания и переносы строк.
 func tokenize(text string) []string {
-	// Используем регулярное выражение из additional_functions для токенизации
-	tokens := additional_functions.RegToken.FindAllString(text, -1)
+	// Используем регулярное выражение токенизатора с текущими настройками
+	tokens := tokenRegexp.FindAllString(text, -1)
 
-	// Заменяем токены, содержащие перенос строки, на символ '\n'
-	for i, token := range tokens {
+	result := []string{}
+	for _, token := range tokens {
+		// Заменяем токены, содержащие перенос строки, на символ '\n'
 		if strings.Contains(token, "\n") {
-			tokens[i] = "\n"
+			result = append(result, "\n")
+			continue
+		}
+
+		// Теги приводятся к записи (name, params); похожий на тег текст с неизвестным именем
+		// остаётся обычным текстом: (sic), (see, above)
+		if tagToken.MatchString(token) {
+			result = append(result, tagTokens(token)...)
+			continue
 		}
+		result = append(result, token)
 	}
-	return tokens
+	return result
 }
 
 // ProcessText выполняет все этапы обработки текста: токенизация, трансформация, корректировка пунктуации,
-// обработка апострофов и исправление артиклей.
+// обработка кавычек и апострофов и исправление артиклей. Части текста, отключённые директивами
+// (reloaded:off) … (reloaded:on), остаются без изменений, а в частях с (reloaded:off articles)
+// пропускаются только перечисленные этапы.
 func ProcessText(text string) string {
-	tokens := tokenize(text)                  // Токенизация
-	transformedTokens := ProcessTags(tokens)  // Обработка пользовательских тегов (реализация отдельно)
-	result := joinTokens(transformedTokens)   // Объединение токенов в строку
-	result = CorrectPunctuation(result)       // Корректировка пунктуации
-	result = handleApostrophes(result)        // Обработка апострофов
-	result = CorrectArticles(result)          // Исправление артиклей ("a"/"an")
+	resetDiagnostics() // Очистка замечаний предыдущего запуска
+	resetChanges()     // Очистка журнала замен
+
+	regions := splitRegions(text)
+	var sb strings.Builder
+	for i, r := range regions {
+		if r.disabled == nil || strings.TrimSpace(r.text) == "" {
+			sb.WriteString(r.text) // Обработка отключена — текст как есть
+			continue
+		}
+
+		// Пробелы на границе с соседней частью сохраняются, чтобы части текста не слипались
+		leading, trailing := "", ""
+		if i > 0 {
+			leading = r.text[:len(r.text)-len(strings.TrimLeft(r.text, " \t"))]
+		}
+		if i < len(regions)-1 {
+			trailing = r.text[len(strings.TrimRight(r.text, " \t")):]
+		}
+		continued := continuesSentence(sb.String(), r.text) // Предложение начато до директивы
+		sb.WriteString(leading + processRegion(r.text, r.disabled, continued) + trailing)
+	}
+	return sb.String()
+}
+
+// processRegion обрабатывает часть текста, пропуская отключённые этапы.
+// continued — часть начинается внутри предложения, начатого в предыдущей части.
+func processRegion(text string, off map[string]bool, continued bool) string {
+
+	if !off["quotes"] {
+		text = normalizeQuotes(text) // Типографские кавычки → прямые
+	}
+	if sentenceCase && !off["case"] {
+		text = capitalizeSentences(text, continued) // Заглавные буквы в начале предложений
+	}
+	tokens := tokenize(text) // Токенизация
+	if !off["replace"] {
+		tokens = applyReplaceRules(tokens) // Замены из файла правил стиля, только в словах
+	}
+	if !off["tags"] {
+		tokens = ProcessTags(tokens) // Обработка пользовательских тегов
+	}
+	if !off["quotes"] {
+		tokens = pairQuotes(tokens) // Поиск пар кавычек с учётом абзацев
+	}
+	if !off["dashes"] {
+		tokens = normalizeDashes(tokens) // Дефисы, тире и диапазоны чисел
+	}
+	result := joinTokens(tokens) // Объединение токенов в строку
+	if !off["punctuation"] {
+		result = CorrectPunctuation(result)   // Корректировка пунктуации
+		result = NormalizePunctuation(result) // Многоточия и повторяющиеся знаки по выбранным правилам
+	}
+	if !off["quotes"] {
+		result = handleDoubleQuotes(result) // Обработка двойных кавычек
+	}
+	if !off["apostrophes"] {
+		result = handleApostrophes(result) // Обработка апострофов
+	}
+	if !off["articles"] {
+		result = CorrectArticles(result) // Исправление артиклей ("a"/"an")
+	}
+	if !off["quotes"] {
+		result = smartenQuotes(result) // Типографские кавычки, если выбран стиль
+	}
 	return result
 }
@@ -0,0 +1,221 @@
+package text_processing
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// pronunciationDict — встроенный словарь произношений в формате CMU
+//
+//go:embed pronunciation.dict
+var pronunciationDict string
+
+// vowelPhonemes — гласные фонемы ARPAbet (без метки ударения)
+var vowelPhonemes = map[string]bool{
+	"AA": true, "AE": true, "AH": true, "AO": true, "AW": true, "AY": true,
+	"EH": true, "ER": true, "EY": true, "IH": true, "IY": true,
+	"OW": true, "OY": true, "UH": true, "UW": true,
+}
+
+// lexicon — начинается ли слово из словаря с гласного звука; ключ — слово в нижнем регистре
+var lexicon = parseLexicon(pronunciationDict)
+
+// lexiconSuffixes — окончания, которые отбрасываются при поиске основы слова в словаре
+var lexiconSuffixes = []string{"'s", "s", "es", "ly", "ed", "ing", "ness", "ful", "ment", "er", "est"}
+
+// vowelLetterNames — буквы, название которых начинается с гласного звука: "an F", "an M", "an X"
+const vowelLetterNames = "aefhilmnorsx"
+
+// wordAcronyms — аббревиатуры, которые читаются как слова, а не по буквам: a NASA probe, an OPEC meeting.
+// Значение — начинается ли чтение с гласного звука: UNESCO читается с [ju], поэтому a UNESCO site.
+var wordAcronyms = map[string]bool{
+	"aids": true, "asap": true, "captcha": false, "fifa": false, "gif": false, "laser": false,
+	"nafta": false, "nasa": false, "nato": false, "opec": true, "radar": false, "scuba": false,
+	"sonar": false, "unesco": false, "unicef": false, "wasp": false, "yolo": false,
+}
+
+// ambiguousAcronyms — аббревиатуры с двумя распространёнными вариантами чтения.
+// Значение — начинается ли с гласного звука чтение словом: SQL — "sequel", URL — "earl", FAQ — "fack".
+var ambiguousAcronyms = map[string]bool{
+	"faq": false,
+	"sql": false,
+	"url": true,
+}
+
+// Варианты оформления неоднозначных аббревиатур
+const (
+	AcronymLetters = "letters" // по буквам: an SQL query
+	AcronymWord    = "word"    // словом: a SQL query
+)
+
+// acronymStyle — принятое в документе чтение неоднозначных аббревиатур
+var acronymStyle = AcronymLetters
+
+// SetAcronymStyle задаёт чтение неоднозначных аббревиатур: AcronymLetters или AcronymWord.
+func SetAcronymStyle(style string) error {
+	if style != AcronymLetters && style != AcronymWord {
+		return fmt.Errorf("неизвестное чтение аббревиатур: %s", style)
+	}
+	acronymStyle = style
+	return nil
+}
+
+// AddWordAcronyms добавляет аббревиатуры, которые читаются как слова. Начальный звук
+// определяется по словарю и правилам чтения, как у обычного слова.
+func AddWordAcronyms(acronyms []string) {
+	for _, acronym := range acronyms {
+		acronym = strings.ToLower(strings.TrimSpace(acronym))
+		if acronym == "" {
+			continue
+		}
+		vowel, ok := lookupLexicon(acronym)
+		if !ok {
+			vowel = letterToSound(acronym)
+		}
+		wordAcronyms[acronym] = vowel
+	}
+}
+
+// acronymVowelSound определяет начальный звук аббревиатуры, которая читается по буквам
+func acronymVowelSound(acronym string) bool {
+	if vowel, ok := ambiguousAcronyms[acronym]; ok && acronymStyle == AcronymWord {
+		return vowel
+	}
+	return strings.ContainsRune(vowelLetterNames, []rune(acronym)[0])
+}
+
+// parseLexicon разбирает словарь: для каждого слова запоминает, гласная ли его первая фонема
+func parseLexicon(dict string) map[string]bool {
+	result := map[string]bool{}
+	for _, line := range strings.Split(dict, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], ";;;") {
+			continue
+		}
+		phoneme := strings.TrimRight(fields[1], "012")
+		result[strings.ToLower(fields[0])] = vowelPhonemes[phoneme]
+	}
+	return result
+}
+
+// lookupLexicon ищет слово в словаре, в том числе по основе без типичных окончаний
+func lookupLexicon(word string) (bool, bool) {
+	if vowel, ok := lexicon[word]; ok {
+		return vowel, true
+	}
+	for _, suffix := range lexiconSuffixes {
+		if stem := strings.TrimSuffix(word, suffix); stem != word {
+			if vowel, ok := lexicon[stem]; ok {
+				return vowel, true
+			}
+		}
+	}
+	return false, false
+}
+
+// letterToSound определяет начальный звук слова, которого нет в словаре, по правилам чтения
+func letterToSound(word string) bool {
+	runes := []rune(word)
+	if !strings.ContainsRune("aeiou", runes[0]) {
+		return false
+	}
+	if len(runes) < 3 {
+		return true
+	}
+
+	// eu- и ewe- читаются с [ju]: a euphonium, a ewer
+	if strings.HasPrefix(word, "eu") || strings.HasPrefix(word, "ewe") {
+		return false
+	}
+
+	// uni-, us-, ut-, ur-, uk- перед гласной читаются с [ju]: a unicycle, a usurper, a urinalysis
+	if runes[0] == 'u' {
+		if strings.HasPrefix(word, "uni") {
+			// un- как приставка отрицания: an uninvited, an unimaginable, an unidentified
+			return len(runes) > 3 && strings.ContainsRune("nmd", runes[3])
+		}
+		if strings.ContainsRune("strk", runes[1]) && strings.ContainsRune("aeiou", runes[2]) {
+			return false
+		}
+	}
+	return true
+}
+
+// numberVowelSound определяет начальный звук числа так, как оно произносится:
+// 8, 80, 8000 — "eight…"; 11, 18, 11 000 — "eleven…", "eighteen…"; годы 1100 и 1800 (без разделителя разрядов) — "eleven hundred";
+// остальные числа начинаются с согласного: a 1,000 ("one thousand"), a 110 ("one hundred ten").
+func numberVowelSound(number string) bool {
+	digits := []rune{}
+	grouped := false // есть ли разделитель разрядов: 1,800 — число, а не год
+	for _, r := range number {
+		if unicode.IsDigit(r) {
+			digits = append(digits, r)
+		} else if r == ',' {
+			grouped = true
+		} else {
+			break // дробная часть, порядковый суффикс или знак процента не влияют на начало
+		}
+	}
+
+	if digits[0] == '8' {
+		return true
+	}
+	if len(digits) >= 2 && digits[0] == '1' && (digits[1] == '1' || digits[1] == '8') {
+		return len(digits)%3 == 2 || (len(digits) == 4 && !grouped)
+	}
+	return false
+}
+
+// startsWithVowelSound определяет, начинается ли произношение слова с гласного звука.
+// Если spelled == true, слово — аббревиатура: обычно она читается по буквам, и важно название
+// первой буквы, но аббревиатуры из wordAcronyms читаются как слова.
+func startsWithVowelSound(word string, spelled bool) bool {
+	word = strings.ToLower(strings.TrimLeftFunc(word, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}))
+	if word == "" {
+		return false
+	}
+
+	// Пользовательские исключения важнее встроенных правил
+	if vowel, ok := matchArticleException(strings.TrimRightFunc(word, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})); ok {
+		return vowel
+	}
+
+	// Для составных слов важна первая часть: an hour-long, a one-time
+	if first, _, found := strings.Cut(word, "-"); found && first != "" {
+		word = first
+		if vowel, ok := matchArticleException(word); ok {
+			return vowel
+		}
+	}
+
+	runes := []rune(strings.TrimRightFunc(word, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}))
+	if len(runes) == 0 {
+		return false
+	}
+	if unicode.IsDigit(runes[0]) {
+		return numberVowelSound(string(runes))
+	}
+	if len(runes) == 1 {
+		return strings.ContainsRune(vowelLetterNames, runes[0])
+	}
+	// Аббревиатуры, которые читаются как слова, имеют своё произношение
+	if vowel, ok := wordAcronyms[string(runes)]; ok {
+		return vowel
+	}
+	if spelled {
+		return acronymVowelSound(string(runes))
+	}
+
+	if vowel, ok := lookupLexicon(string(runes)); ok {
+		return vowel
+	}
+	return letterToSound(string(runes))
+}
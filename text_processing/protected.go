@@ -0,0 +1,72 @@
+package text_processing
+
+import (
+	"go_reloaded/additional_functions"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// customProtected — пользовательские защищённые шаблоны, проверяются раньше встроенных
+var customProtected []string
+
+// tokenRegexp — регулярное выражение токенизатора для выбранной записи тегов и защищённых шаблонов,
+// protectedRegexp — только защищённые шаблоны, для этапов, которые работают с текстом до токенизации,
+// protectedToken — проверка, что токен целиком защищённый.
+// Меняются только через AddProtectedPattern, SetTagSyntax и ResetTokenizer.
+var (
+	tokenRegexp     = buildTokenRegexp()
+	protectedRegexp = buildProtectedRegexp()
+	protectedToken  = anchored(protectedRegexp.String())
+)
+
+// protectedPatterns возвращает пользовательские и встроенные защищённые шаблоны в порядке проверки
+func protectedPatterns() []string {
+	return append(append([]string{}, customProtected...), additional_functions.ProtectedPatterns...)
+}
+
+// buildTokenRegexp собирает токенизатор из текущих настроек
+func buildTokenRegexp() *regexp.Regexp {
+	return additional_functions.BuildTokenRegexp(currentTagSyntax.pattern, protectedPatterns())
+}
+
+// buildProtectedRegexp собирает выражение, находящее защищённые токены в тексте
+func buildProtectedRegexp() *regexp.Regexp {
+	return regexp.MustCompile(strings.Join(protectedPatterns(), "|"))
+}
+
+// protectedRunes отмечает символы текста, входящие в защищённые токены (адреса, e-mail, числа)
+func protectedRunes(text string) []bool {
+	marks := make([]bool, utf8.RuneCountInString(text))
+	for _, span := range protectedRegexp.FindAllStringIndex(text, -1) {
+		from := utf8.RuneCountInString(text[:span[0]])
+		to := from + utf8.RuneCountInString(text[span[0]:span[1]])
+		for i := from; i < to; i++ {
+			marks[i] = true
+		}
+	}
+	return marks
+}
+
+// AddProtectedPattern добавляет шаблон токенов, которые нельзя разбивать (например, номера
+// артикулов или хэштеги). Пользовательские шаблоны проверяются раньше встроенных.
+func AddProtectedPattern(pattern string) error {
+	if _, err := regexp.Compile(pattern); err != nil {
+		return err
+	}
+	customProtected = append([]string{pattern}, customProtected...)
+	tokenRegexp = buildTokenRegexp()
+	protectedRegexp = buildProtectedRegexp()
+	protectedToken = anchored(protectedRegexp.String())
+	return nil
+}
+
+// ResetTokenizer убирает пользовательские защищённые шаблоны и возвращает запись тегов по умолчанию: (up, 2).
+func ResetTokenizer() {
+	customProtected = nil
+	currentTagSyntax = tagSyntaxes["parens"]
+	tagToken = anchored(currentTagSyntax.pattern)
+	tokenRegexp = buildTokenRegexp()
+	protectedRegexp = buildProtectedRegexp()
+	protectedToken = anchored(protectedRegexp.String())
+}
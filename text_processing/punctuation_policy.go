@@ -0,0 +1,100 @@
+package text_processing
+
+import (
+	"fmt"
+	"go_reloaded/additional_functions"
+	"strings"
+)
+
+// PunctuationPolicy описывает правила нормализации повторяющихся знаков препинания.
+// Нулевое значение ничего не меняет.
+type PunctuationPolicy struct {
+	Ellipsis    string // на что заменять серии из двух и более точек: "...", "…" или "" (не менять)
+	MaxRepeat   int    // сколько одинаковых ! или ? подряд оставлять; 0 — без ограничения
+	Interrobang bool   // заменять сочетания ?! и !? на ‽
+	NoMixed     bool   // запрещать смешанные серии вроде ,,;; — остаётся первый знак
+}
+
+// punctuationPolicy — правила нормализации, заданные через SetPunctuationPolicy
+var punctuationPolicy PunctuationPolicy
+
+// SetPunctuationPolicy задаёт правила нормализации повторяющихся знаков препинания.
+func SetPunctuationPolicy(policy PunctuationPolicy) error {
+	if policy.Ellipsis != "" && policy.Ellipsis != "..." && policy.Ellipsis != "…" {
+		return fmt.Errorf("неизвестное оформление многоточия: %s", policy.Ellipsis)
+	}
+	if policy.MaxRepeat < 0 {
+		return fmt.Errorf("некорректное ограничение повторов: %d", policy.MaxRepeat)
+	}
+	punctuationPolicy = policy
+	return nil
+}
+
+// NormalizePunctuation применяет правила нормализации к каждой серии знаков препинания.
+// Выполняется после CorrectPunctuation, когда знаки уже собраны в серии без пробелов.
+func NormalizePunctuation(text string) string {
+	policy := punctuationPolicy
+	if policy == (PunctuationPolicy{}) {
+		return text
+	}
+	return additional_functions.PunctuationRun.ReplaceAllStringFunc(text, func(run string) string {
+		return normalizeRun(run, policy)
+	})
+}
+
+// normalizeRun нормализует одну серию знаков препинания
+func normalizeRun(run string, policy PunctuationPolicy) string {
+	// Смешанная серия с запятыми, точками с запятой или двоеточиями: ,,;; → ,
+	if policy.NoMixed && isMixedRun(run) {
+		addDiagnostic("punctuation", run, "смешанная серия знаков препинания")
+		return string([]rune(run)[0])
+	}
+
+	// Многоточие: серии из двух и более точек
+	if policy.Ellipsis != "" {
+		run = additional_functions.DotRun.ReplaceAllString(run, policy.Ellipsis)
+	}
+
+	// Вопрос и восклицание вместе: ?! → ‽
+	if policy.Interrobang && strings.Trim(run, "?!") == "" && strings.Contains(run, "?") && strings.Contains(run, "!") {
+		return "‽"
+	}
+
+	// Ограничение повторов одинаковых ! и ?
+	if policy.MaxRepeat > 0 {
+		var sb strings.Builder
+		var prev rune
+		repeat := 0
+		for _, r := range run {
+			if r == prev {
+				repeat++
+			} else {
+				prev, repeat = r, 1
+			}
+			if (r == '!' || r == '?') && repeat > policy.MaxRepeat {
+				continue
+			}
+			sb.WriteRune(r)
+		}
+		run = sb.String()
+	}
+	return run
+}
+
+// isMixedRun проверяет, смешаны ли в серии запятые, точки с запятой или двоеточия с другими знаками.
+// Сочетания знаков конца предложения (?!, ?..) и точка сокращения перед запятой (e.g., etc.;) смешанными не считаются.
+func isMixedRun(run string) bool {
+	if strings.Trim(run, ",;:") != "" && strings.Trim(run, ".!?…‽") == "" {
+		return false
+	}
+	if len(run) == 2 && run[0] == '.' && strings.ContainsRune(",;:", rune(run[1])) {
+		return false
+	}
+	first := []rune(run)[0]
+	for _, r := range run {
+		if r != first {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,190 @@
+package text_processing
+
+import "strings"
+
+// Роли кавычек в потоке токенов
+const (
+	quoteNone  = iota // токен не содержит кавычек или они не участвуют в паре
+	quoteOpen         // отдельная открывающая кавычка
+	quoteClose        // отдельная закрывающая кавычка
+)
+
+// openQuote — открытая кавычка в стеке вложенности: вид кавычки и токен, в котором она стоит
+type openQuote struct {
+	mark  byte
+	token string
+}
+
+// isParagraphBreak проверяет, начинается ли с позиции i граница абзаца (пустая строка)
+func isParagraphBreak(tokens []string, i int) bool {
+	return tokens[i] == "\n" && i+1 < len(tokens) && tokens[i+1] == "\n"
+}
+
+// quoteTokensAfter считает одинарные кавычки в токенах после позиции i до конца абзаца
+func quoteTokensAfter(tokens []string, i int) int {
+	count := 0
+	for j := i + 1; j < len(tokens) && !isParagraphBreak(tokens, j); j++ {
+		token := tokens[j]
+		if token == "'" {
+			count++
+			continue
+		}
+		if leadingQuote(token) == '\'' {
+			count++
+		}
+		if len(token) >= 2 && token != "'n'" && token[len(token)-1] == '\'' {
+			count++
+		}
+	}
+	return count
+}
+
+// leadingQuote возвращает кавычку, открывающую токен, или 0. Пропуски вроде 'tis и '90s кавычками не считаются.
+func leadingQuote(token string) byte {
+	switch {
+	case token == "":
+		return 0
+	case token[0] == '"':
+		return '"'
+	case token[0] == '\'' && (len(token) == 1 || !isElision([]rune(token[1:]))):
+		return '\''
+	}
+	return 0
+}
+
+// pairQuotes находит пары кавычек в потоке токенов с учётом вложенности и границ абзацев.
+// Отдельно стоящие кавычки приклеиваются к соседнему слову: открывающая — к следующему,
+// закрывающая — к предыдущему. Кавычка может продолжаться на следующей строке, но не в следующем абзаце:
+// незакрытая в конце абзаца кавычка попадает в диагностику, если следующий абзац не начинается
+// с такой же кавычки (так оформляются цитаты из нескольких абзацев).
+func pairQuotes(tokens []string) []string {
+	roles := make([]int, len(tokens))
+	stack := []openQuote{}
+
+	// findOpen возвращает позицию кавычки mark в стеке или -1
+	findOpen := func(mark byte) int {
+		for j := len(stack) - 1; j >= 0; j-- {
+			if stack[j].mark == mark {
+				return j
+			}
+		}
+		return -1
+	}
+
+	// closeQuote закрывает кавычку mark; вложенные незакрытые кавычки попадают в диагностику
+	closeQuote := func(pos int) {
+		for _, inner := range stack[pos+1:] {
+			addDiagnostic("quotes", inner.token, "кавычка не закрыта до конца внешней цитаты")
+		}
+		stack = stack[:pos]
+	}
+
+	// continuation — продолжается ли цитата в следующем абзаце
+	continuation := false
+
+	for i, token := range tokens {
+		if token == "\n" {
+			if !isParagraphBreak(tokens, i) || len(stack) == 0 {
+				continue
+			}
+
+			// Граница абзаца: цитата продолжается, если следующий абзац открывается той же кавычкой
+			next := i
+			for next < len(tokens) && tokens[next] == "\n" {
+				next++
+			}
+			if next < len(tokens) && leadingQuote(tokens[next]) == stack[len(stack)-1].mark {
+				continuation = true
+				continue
+			}
+			for _, open := range stack {
+				addDiagnostic("quotes", open.token, "кавычка не закрыта в конце абзаца")
+			}
+			stack = stack[:0]
+			continue
+		}
+
+		// Одиночная кавычка: открывает, если такая же ещё не открыта, иначе закрывает
+		if token == "\"" || token == "'" {
+			mark := token[0]
+			if continuation {
+				continuation = false
+				roles[i] = quoteOpen
+			} else if pos := findOpen(mark); pos >= 0 {
+				closeQuote(pos)
+				roles[i] = quoteClose
+			} else {
+				stack = append(stack, openQuote{mark: mark, token: token})
+				roles[i] = quoteOpen
+			}
+			continue
+		}
+
+		// Кавычка в начале слова: 'hello, "hello
+		if mark := leadingQuote(token); mark != 0 {
+			if continuation {
+				continuation = false
+			} else {
+				stack = append(stack, openQuote{mark: mark, token: token})
+			}
+		}
+		continuation = false
+
+		// Кавычка в конце слова: hello', hello"
+		if len(token) < 2 || token == "'n'" {
+			continue
+		}
+		last := token[len(token)-1]
+		if last != '"' && last != '\'' {
+			continue
+		}
+		// Апостроф после s или in закрывает кавычку, только если дальше в абзаце нет непарной: ' the cats' toys '
+		possessive := last == '\'' && (strings.HasSuffix(token, "s'") || strings.HasSuffix(token, "in'"))
+		if pos := findOpen(last); pos >= 0 && (!possessive || quoteTokensAfter(tokens, i)%2 == 0) {
+			closeQuote(pos)
+			continue
+		}
+		// Без открытой кавычки апостроф после s или in — притяжательная форма или пропуск: students', goin'
+		if possessive {
+			continue
+		}
+		addDiagnostic("quotes", token, "закрывающая кавычка без открывающей")
+	}
+
+	// Конец текста — тоже конец абзаца
+	for _, open := range stack {
+		addDiagnostic("quotes", open.token, "кавычка не закрыта в конце абзаца")
+	}
+
+	return glueQuotes(tokens, roles)
+}
+
+// glueQuotes приклеивает отдельные кавычки к соседним токенам в соответствии с их ролью
+func glueQuotes(tokens []string, roles []int) []string {
+	result := []string{}
+	prefix := ""
+	for i, token := range tokens {
+		switch {
+		case roles[i] == quoteOpen:
+			prefix += token
+			continue
+		case roles[i] == quoteClose && prefix == "" && len(result) > 0 && result[len(result)-1] != "\n":
+			result[len(result)-1] += token
+			continue
+		}
+
+		if prefix != "" {
+			if token == "\n" {
+				result = append(result, prefix)
+			} else {
+				token = prefix + token
+			}
+			prefix = ""
+		}
+		result = append(result, token)
+	}
+	if prefix != "" {
+		result = append(result, prefix)
+	}
+	return result
+}
@@ -0,0 +1,208 @@
+package text_processing
+
+import (
+	"fmt"
+	"go_reloaded/additional_functions"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ReplaceRule — правило поиска и замены из файла правил стиля.
+type ReplaceRule struct {
+	ID           string         // идентификатор правила в журнале изменений
+	Pattern      *regexp.Regexp // что искать
+	Replacement  string         // на что заменить; в правилах-выражениях допустимы $1, ${name}
+	Regexp       bool           // шаблон записан как /…/; замена в остальных правилах вставляется как есть
+	WholeWord    bool           // совпадение только целым словом
+	PreserveCase bool           // искать без учёта регистра и повторять регистр найденного: Utilize → Use, UTILIZE → USE
+}
+
+// replaceRules — правила, применяемые к тексту по порядку
+var replaceRules []ReplaceRule
+
+// SetReplaceRules задаёт правила поиска и замены. Вызов с nil убирает все правила.
+func SetReplaceRules(rules []ReplaceRule) {
+	replaceRules = rules
+}
+
+// ParseReplaceRules разбирает строки вида "id: шаблон => замена | параметры".
+// Идентификатор необязателен (по умолчанию rule-N), шаблон в /…/ — регулярное выражение,
+// иначе — строка как есть. Параметры через запятую: word — только целым словом, case — с сохранением регистра.
+// Например: "go: Golang => Go | word" или "/utili[sz]e/ => use | word, case".
+// Правила применяются к отдельным словам, поэтому шаблон с пробелами ("in order to", /a\sb/) считается ошибкой.
+func ParseReplaceRules(lines []string) ([]ReplaceRule, error) {
+	var rules []ReplaceRule
+	for n, line := range lines {
+		rule := ReplaceRule{ID: fmt.Sprintf("rule-%d", n+1)}
+		body := line
+
+		// Идентификатор: слово с двоеточием и пробелом в начале строки
+		if id, rest, ok := strings.Cut(body, ": "); ok && isRuleID(id) {
+			rule.ID, body = id, rest
+		}
+
+		// Параметры после последнего " | "
+		if i := strings.LastIndex(body, " | "); i >= 0 {
+			for _, option := range strings.Split(body[i+3:], ",") {
+				switch strings.ToLower(strings.TrimSpace(option)) {
+				case "word":
+					rule.WholeWord = true
+				case "case":
+					rule.PreserveCase = true
+				default:
+					return nil, fmt.Errorf("неизвестный параметр %q в правиле %q", strings.TrimSpace(option), line)
+				}
+			}
+			body = body[:i]
+		}
+
+		pattern, replacement, ok := strings.Cut(body, " => ")
+		pattern = strings.TrimSpace(pattern)
+		if !ok || pattern == "" {
+			return nil, fmt.Errorf("некорректное правило замены: %q", line)
+		}
+		rule.Replacement = strings.TrimSpace(replacement)
+
+		if len(pattern) > 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+			pattern = pattern[1 : len(pattern)-1]
+			rule.Regexp = true
+		} else {
+			pattern = regexp.QuoteMeta(pattern)
+		}
+		if rule.PreserveCase {
+			pattern = "(?i)" + pattern
+		}
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка в шаблоне правила %q: %v", line, err)
+		}
+		if parsed, _ := syntax.Parse(pattern, syntax.Perl); parsed != nil && matchesSpace(parsed) {
+			return nil, fmt.Errorf("шаблон правила %q содержит пробел, а правила применяются к отдельным словам", line)
+		}
+		rule.Pattern = compiled
+
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// matchesSpace проверяет, есть ли в выражении часть, совпадающая только с пробельными символами: пробел, \s, [ \t]
+func matchesSpace(re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			if unicode.IsSpace(r) {
+				return true
+			}
+		}
+	case syntax.OpCharClass:
+		spaceOnly := len(re.Rune) > 0
+		for j := 0; j+1 < len(re.Rune) && spaceOnly; j += 2 {
+			for r := re.Rune[j]; r <= re.Rune[j+1] && spaceOnly; r++ {
+				spaceOnly = unicode.IsSpace(r)
+			}
+		}
+		if spaceOnly {
+			return true
+		}
+	}
+	for _, sub := range re.Sub {
+		if matchesSpace(sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRuleID проверяет, может ли строка быть идентификатором правила: буквы, цифры, - и _
+func isRuleID(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !isWordRune(r) && r != '-' && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// applyReplaceRules применяет правила замены к словам по порядку и записывает каждую замену в журнал изменений.
+// Теги, защищённые токены (адреса, e-mail, числа), знаки препинания, кавычки и скобки не меняются.
+func applyReplaceRules(tokens []string) []string {
+	if len(replaceRules) == 0 {
+		return tokens
+	}
+	result := make([]string, len(tokens))
+	for i, token := range tokens {
+		result[i] = token
+		if !additional_functions.IsWord(token) || protectedToken.MatchString(token) {
+			continue
+		}
+		for _, rule := range replaceRules {
+			result[i] = replaceInWord(rule, result[i])
+		}
+	}
+	return result
+}
+
+// replaceInWord применяет одно правило к слову
+func replaceInWord(rule ReplaceRule, word string) string {
+	var sb strings.Builder
+	last := 0
+	for _, m := range rule.Pattern.FindAllStringSubmatchIndex(word, -1) {
+		if m[0] == m[1] {
+			continue // пустые совпадения не заменяем
+		}
+		if rule.WholeWord && !isWholeWord(word, m[0], m[1]) {
+			continue
+		}
+
+		before := word[m[0]:m[1]]
+		after := rule.Replacement
+		if rule.Regexp {
+			after = string(rule.Pattern.ExpandString(nil, rule.Replacement, word, m))
+		}
+		if rule.PreserveCase {
+			after = matchCase(before, after)
+		}
+
+		sb.WriteString(word[last:m[0]])
+		sb.WriteString(after)
+		last = m[1]
+		if before != after {
+			addChange(rule.ID, before, after)
+		}
+	}
+	sb.WriteString(word[last:])
+	return sb.String()
+}
+
+// isWholeWord проверяет, что фрагмент text[start:end] не продолжает соседние слова
+func isWholeWord(text string, start, end int) bool {
+	before, _ := utf8.DecodeLastRuneInString(text[:start])
+	after, _ := utf8.DecodeRuneInString(text[end:])
+	return (start == 0 || !isWordRune(before)) && (end == len(text) || !isWordRune(after))
+}
+
+// matchCase переносит регистр найденного текста на замену: всё заглавными или с заглавной буквы
+func matchCase(found, replacement string) string {
+	letters := []rune{}
+	for _, r := range found {
+		if unicode.IsLetter(r) {
+			letters = append(letters, r)
+		}
+	}
+	switch {
+	case len(letters) == 0:
+		return replacement
+	case len(letters) > 1 && strings.ToUpper(found) == found:
+		return strings.ToUpper(replacement)
+	case unicode.IsUpper(letters[0]):
+		return upperFirstLetter(replacement)
+	}
+	return replacement
+}
@@ -0,0 +1,103 @@
+package text_processing
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// sentenceCase — включён ли этап заглавных букв в начале предложений
+var sentenceCase = false
+
+// SetSentenceCase включает или выключает этап, который делает заглавной первую букву каждого
+// предложения и местоимение "i". Этап выполняется до тегов, поэтому явный (low) важнее.
+func SetSentenceCase(enabled bool) {
+	sentenceCase = enabled
+}
+
+// capitalizeSentences делает заглавной первую букву каждого предложения (границы находит sentenceSpans)
+// и отдельно стоящее местоимение "i", в том числе в i'm, i'll, i've, i'd.
+// Адреса и e-mail в начале предложения не меняются. Если continued, текст продолжает предложение
+// из предыдущей части документа и первая буква не меняется.
+func capitalizeSentences(text string, continued bool) string {
+	runes := []rune(text)
+
+	for n, span := range sentenceSpans(text) {
+		if n == 0 && continued {
+			continue
+		}
+		i := utf8.RuneCountInString(text[:span[0]])
+		for i < len(runes) && (unicode.IsSpace(runes[i]) || strings.ContainsRune(openingMarks, runes[i])) {
+			i++
+		}
+		if i >= len(runes) || !unicode.IsLetter(runes[i]) {
+			continue
+		}
+
+		wordEnd := i
+		for wordEnd < len(runes) && !unicode.IsSpace(runes[wordEnd]) {
+			wordEnd++
+		}
+		word := string(runes[i:wordEnd])
+		if strings.Contains(word, "@") || strings.Contains(word, "://") {
+			continue
+		}
+		runes[i] = unicode.ToUpper(runes[i])
+	}
+
+	// Местоимение i: отдельное слово после пробела или открывающей кавычки, но не часть сокращения i.e.
+	// и не часть адреса или e-mail: example.com/i/page, i@example.com
+	protected := protectedRunes(text)
+	for i, r := range runes {
+		if r != 'i' || protected[i] || !isWordStart(runes, i) {
+			continue
+		}
+		next := runeAt(runes, i+1)
+		if isWordRune(next) || (next == '.' && unicode.IsLetter(runeAt(runes, i+2))) {
+			continue
+		}
+		if next == '\'' && !isPronounContraction(runes[i+2:]) {
+			continue
+		}
+		runes[i] = 'I'
+	}
+	return string(runes)
+}
+
+// continuesSentence проверяет, продолжает ли text предложение, начатое в тексте before:
+// границы предложений ищутся в тексте целиком, как если бы директив между частями не было
+func continuesSentence(before, text string) bool {
+	if strings.TrimSpace(before) == "" {
+		return false
+	}
+	joined := before + text
+	for _, span := range sentenceSpans(joined) {
+		if span[1] > len(before) {
+			return span[0] < len(before) && strings.TrimSpace(joined[span[0]:len(before)]) != ""
+		}
+	}
+	return false
+}
+
+// isWordStart проверяет, что перед позицией i начало текста, пробел или открывающая кавычка либо скобка,
+// которой предшествует пробел
+func isWordStart(runes []rune, i int) bool {
+	prev := runeAt(runes, i-1)
+	if strings.ContainsRune(openingMarks, prev) {
+		prev = runeAt(runes, i-2)
+	}
+	return prev == 0 || unicode.IsSpace(prev)
+}
+
+// isPronounContraction проверяет, является ли текст после "i'" окончанием сокращения: i'm, i'll, i've, i'd
+func isPronounContraction(rest []rune) bool {
+	end := 0
+	for end < len(rest) && isWordRune(rest[end]) {
+		end++
+	}
+	switch strings.ToLower(string(rest[:end])) {
+	case "m", "ll", "ve", "d":
+		return true
+	}
+	return false
+}
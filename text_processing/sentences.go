@@ -0,0 +1,170 @@
+package text_processing
+
+import (
+	"strings"
+	"unicode"
+)
+
+// abbreviations — сокращения, после точки в которых предложение не заканчивается: Mr. Smith, e.g. this.
+// Хранятся в нижнем регистре, без завершающей точки.
+var abbreviations = wordSet([]string{
+	"mr", "mrs", "ms", "dr", "prof", "sr", "jr", "st", "mt", "rev", "gen", "col", "capt", "lt", "sgt", "hon",
+	"vs", "cf", "e.g", "i.e", "u.s", "u.k", "approx", "dept", "inc", "ltd",
+	"jan", "feb", "mar", "apr", "jun", "jul", "aug", "sep", "sept", "oct", "nov", "dec",
+})
+
+// numberAbbreviations — сокращения, совпадающие с обычными словами: сокращением они считаются,
+// только если за ними идёт число (No. 5, p. 12, fig. 3, est. 1990), иначе точка завершает предложение (He said no.)
+var numberAbbreviations = wordSet([]string{"no", "p", "pp", "fig", "vol", "est"})
+
+// AddAbbreviations добавляет сокращения, после которых точка не завершает предложение.
+func AddAbbreviations(words []string) {
+	for _, word := range words {
+		abbreviations[strings.ToLower(strings.TrimSuffix(strings.TrimSpace(word), "."))] = true
+	}
+}
+
+// closingMarks — закрывающие кавычки и скобки, которые относятся к концу предложения
+const closingMarks = "'\"”’»)]"
+
+// openingMarks — открывающие кавычки и скобки перед первым словом предложения
+const openingMarks = "'\"“‘«„(["
+
+// Sentences разбивает текст на предложения. Предложение заканчивается на . ! ? или многоточие,
+// за которыми (с закрывающими кавычками и скобками) следует пробел или конец текста, а также на пустой строке.
+// Не считаются концом предложения точки в сокращениях (Mr., e.g.) и инициалах (J. R. R.),
+// дробные числа (3.14), многоточие перед строчной буквой и знак внутри цитаты перед словами автора
+// ("Stop!" he said).
+func Sentences(text string) []string {
+	sentences := []string{}
+	for _, span := range sentenceSpans(text) {
+		if sentence := strings.TrimSpace(text[span[0]:span[1]]); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+	}
+	return sentences
+}
+
+// sentenceSpans возвращает границы предложений в тексте в байтах: [начало, конец)
+func sentenceSpans(text string) [][2]int {
+	spans := [][2]int{}
+	start := 0
+	runes := []rune(text)
+	offsets := make([]int, len(runes)+1) // позиция каждого символа в байтах
+	for i, pos := 0, 0; i < len(runes); i++ {
+		offsets[i] = pos
+		pos += len(string(runes[i]))
+	}
+	offsets[len(runes)] = len(text)
+
+	for i := 0; i < len(runes); i++ {
+		// Пустая строка всегда завершает предложение
+		if runes[i] == '\n' && runeAt(runes, i+1) == '\n' {
+			spans = append(spans, [2]int{start, offsets[i]})
+			for i < len(runes) && unicode.IsSpace(runes[i]) {
+				i++
+			}
+			start = offsets[i]
+			i--
+			continue
+		}
+
+		if !strings.ContainsRune(".!?…", runes[i]) {
+			continue
+		}
+
+		// Серия знаков конца предложения и закрывающих кавычек: ?!, ..., ." и т. п.
+		end := i
+		for end < len(runes) && strings.ContainsRune(".!?…", runes[end]) {
+			end++
+		}
+		terminators := string(runes[i:end])
+		quoteEnd := end
+		for quoteEnd < len(runes) && strings.ContainsRune(closingMarks, runes[quoteEnd]) {
+			quoteEnd++
+		}
+		// Прямая кавычка, отделённая пробелом, но не открывающая следующее слово: ' wow! ' he said
+		spaced := quoteEnd
+		for spaced < len(runes) && (runes[spaced] == ' ' || runes[spaced] == '\t') {
+			spaced++
+		}
+		if spaced > quoteEnd && (runeAt(runes, spaced) == '\'' || runeAt(runes, spaced) == '"') &&
+			(spaced+1 == len(runes) || unicode.IsSpace(runes[spaced+1])) {
+			quoteEnd = spaced + 1
+		}
+
+		if quoteEnd < len(runes) && !unicode.IsSpace(runes[quoteEnd]) {
+			i = end - 1
+			continue // 3.14, e.g.this, example.com — не конец предложения
+		}
+		if isSentenceEnd(runes, i, terminators, quoteEnd > end, nextLetter(runes, quoteEnd)) {
+			spans = append(spans, [2]int{start, offsets[quoteEnd]})
+			start = offsets[quoteEnd]
+		}
+		i = quoteEnd - 1
+	}
+
+	if start < len(text) {
+		spans = append(spans, [2]int{start, len(text)})
+	}
+	return spans
+}
+
+// isSentenceEnd решает, завершает ли серия знаков terminators, начинающаяся с позиции i, предложение.
+// quoted — стоит ли после знаков закрывающая кавычка, next — первая буква следующего слова (0 в конце текста).
+func isSentenceEnd(runes []rune, i int, terminators string, quoted bool, next rune) bool {
+	if next == 0 {
+		return true
+	}
+	lowerNext := unicode.IsLetter(next) && unicode.IsLower(next)
+
+	// Многоточие перед строчной буквой — пауза внутри предложения
+	if (strings.Contains(terminators, "..") || strings.Contains(terminators, "…")) && lowerNext {
+		return false
+	}
+	// Знак внутри цитаты перед словами автора: "Stop!" he said
+	if quoted && lowerNext {
+		return false
+	}
+
+	if terminators == "." {
+		word := previousWord(runes, i)
+		// Инициалы: J. R. R. Tolkien
+		if len([]rune(word)) == 1 && unicode.IsUpper([]rune(word)[0]) {
+			return false
+		}
+		lower := strings.ToLower(word)
+		if abbreviations[lower] {
+			return false
+		}
+		if numberAbbreviations[lower] && unicode.IsDigit(next) {
+			return false
+		}
+		// et al. — сокращение только после et
+		if lower == "al" && strings.ToLower(previousWord(runes, i-len([]rune(word))-1)) == "et" {
+			return false
+		}
+	}
+	return true
+}
+
+// previousWord возвращает слово перед позицией i, включая внутренние точки (e.g)
+func previousWord(runes []rune, i int) string {
+	start := i
+	for start > 0 && (isWordRune(runes[start-1]) || (runes[start-1] == '.' && start-2 >= 0 && isWordRune(runes[start-2]))) {
+		start--
+	}
+	return string(runes[start:i])
+}
+
+// nextLetter возвращает первый значимый символ после позиции i, пропуская пробелы,
+// открывающие кавычки и скобки; 0 — если до конца текста его нет
+func nextLetter(runes []rune, i int) rune {
+	for ; i < len(runes); i++ {
+		if unicode.IsSpace(runes[i]) || strings.ContainsRune(openingMarks, runes[i]) {
+			continue
+		}
+		return runes[i]
+	}
+	return 0
+}
@@ -0,0 +1,70 @@
+package text_processing
+
+import (
+	"fmt"
+	"go_reloaded/additional_functions"
+	"strings"
+)
+
+// quoteMarks — типографские кавычки языка: внешние (двойные) и внутренние (одиночные)
+type quoteMarks struct {
+	doubleOpen, doubleClose string
+	singleOpen, singleClose string
+}
+
+// typographicApostrophe заменяет прямой апостроф в сокращениях: don't → don’t
+const typographicApostrophe = "’"
+
+// quoteStyles — поддерживаемые стили типографских кавычек
+var quoteStyles = map[string]quoteMarks{
+	"en": {"“", "”", "‘", "’"},
+	"ru": {"«", "»", "„", "“"},
+	"de": {"„", "“", "‚", "‘"},
+}
+
+// quoteStyle — стиль кавычек в результате; пустая строка означает прямые кавычки
+var quoteStyle = ""
+
+// SetQuoteStyle задаёт стиль кавычек в результате: "straight" (прямые), "en", "ru" или "de".
+func SetQuoteStyle(style string) error {
+	style = strings.ToLower(style)
+	if style == "straight" || style == "" {
+		quoteStyle = ""
+		return nil
+	}
+	if _, ok := quoteStyles[style]; !ok {
+		return fmt.Errorf("неизвестный стиль кавычек: %s", style)
+	}
+	quoteStyle = style
+	return nil
+}
+
+// curlyQuotesReplacer приводит типографские кавычки во входном тексте к прямым,
+// чтобы дальше они обрабатывались так же, как прямые
+var curlyQuotesReplacer = strings.NewReplacer(
+	"‘", "'", "’", "'", "‚", "'", "‛", "'",
+	"“", "\"", "”", "\"", "„", "\"", "‟", "\"", "«", "\"", "»", "\"",
+)
+
+// normalizeQuotes заменяет типографские кавычки и апострофы на прямые
+func normalizeQuotes(text string) string {
+	return curlyQuotesReplacer.Replace(text)
+}
+
+// smartenQuotes заменяет прямые кавычки на типографские выбранного стиля.
+// Пары находятся так же, как в handleApostrophes и handleDoubleQuotes, а апострофы
+// в сокращениях и притяжательных формах (don't, students') и оставшиеся без пары становятся ’.
+func smartenQuotes(text string) string {
+	marks, ok := quoteStyles[quoteStyle]
+	if !ok {
+		return text
+	}
+
+	// Апострофы в сокращениях и притяжательных формах — не кавычки
+	text = maskApostrophes(text)
+
+	text = additional_functions.PairedDoubleQuotes.ReplaceAllString(text, marks.doubleOpen+"${1}"+marks.doubleClose)
+	text = additional_functions.PairedSingleQuotes.ReplaceAllString(text, marks.singleOpen+"${1}"+marks.singleClose)
+	text = unmaskApostrophes(text, typographicApostrophe)
+	return strings.ReplaceAll(text, "'", typographicApostrophe)
+}
@@ -2,7 +2,6 @@ package text_processing
 
 import (
 	"go_reloaded/additional_functions"
-	"regexp"
 	"strings"
 )
 
@@ -15,9 +14,13 @@ func CorrectPunctuation(text string) string {
 }
 
 // handleApostrophes обрабатывает кавычки-апострофы в тексте:
-// - убирает лишние пробелы внутри одиночных кавычек;
+// - не считает кавычками апострофы в сокращениях и притяжательных формах (don't, students', 'tis);
+// - убирает лишние пробелы внутри одиночных кавычек в пределах строки;
 // - корректно разделяет подряд идущие кавычки (например, ''foo'' → 'foo' 'foo').
 func handleApostrophes(text string) string {
+	// Апострофы в сокращениях и притяжательных формах не участвуют в поиске пар
+	text = maskApostrophes(text)
+
 	// Удаляет пробелы внутри кавычек, например: ' hello ' → 'hello'
 	processed := additional_functions.ApostropheContentWithSpaces.ReplaceAllStringFunc(text, func(match string) string {
 		submatches := additional_functions.ApostropheContentWithSpaces.FindStringSubmatch(match)
@@ -40,14 +43,30 @@ func handleApostrophes(text string) string {
 	})
 
 	// Обработка случая двойных кавычек подряд без пробела: 'foo''bar' → 'foo' 'bar'
-	re1 := regexp.MustCompile(`'([^']*)''([^']*)'`)
-	flagmatchstring, _ := regexp.MatchString(`'([^']*)''([^']*)'`, processed)
-	for flagmatchstring {
+	re1 := additional_functions.SplitAdjacentApostrophes
+	for re1.MatchString(processed) {
 		processed = re1.ReplaceAllString(processed, "'$1' '$2'")
-		flagmatchstring, _ = regexp.MatchString(`'([^']*)''([^']*)'`, processed)
 	}
+	text = processed
+	return unmaskApostrophes(text, "'")
+}
 
-	// Ещё раз применим шаблон, чтобы убедиться, что всё обработано
-	text = re1.ReplaceAllString(processed, "'$1' '$2'")
-	return text
+// handleDoubleQuotes обрабатывает двойные кавычки так же, как handleApostrophes — одиночные:
+// - убирает лишние пробелы внутри кавычек: " hello " → "hello";
+// - разделяет подряд идущие кавычки: "foo""bar" → "foo" "bar".
+// Кавычки ищутся только в пределах строки; пары через строки находит pairQuotes.
+func handleDoubleQuotes(text string) string {
+	// Удаляет пробелы внутри кавычек, сохраняя вложенные одиночные кавычки
+	processed := additional_functions.DoubleQuoteContentWithSpaces.ReplaceAllStringFunc(text, func(match string) string {
+		submatches := additional_functions.DoubleQuoteContentWithSpaces.FindStringSubmatch(match)
+		trimmedContent := strings.TrimSpace(submatches[2])
+		trimmedContent = additional_functions.RemoveSpaceBeforePunct.ReplaceAllString(trimmedContent, "$1")
+		return "\"" + trimmedContent + "\""
+	})
+
+	// Разделяет подряд идущие кавычки
+	for additional_functions.SplitAdjacentDoubleQuotes.MatchString(processed) {
+		processed = additional_functions.SplitAdjacentDoubleQuotes.ReplaceAllString(processed, "\"$1\" \"$2\"")
+	}
+	return processed
 }
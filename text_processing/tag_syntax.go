@@ -0,0 +1,81 @@
+package text_processing
+
+import (
+	"fmt"
+	"go_reloaded/additional_functions"
+	"regexp"
+	"strings"
+)
+
+// tagSyntax описывает запись тегов в тексте: шаблон для токенизатора и ограничители вокруг имени и параметров.
+// Распознанный тег приводится к внутренней записи (name, params), с которой работает ProcessTags.
+type tagSyntax struct {
+	pattern string // регулярное выражение тега
+	open    string // открывающий ограничитель
+	close   string // закрывающий ограничитель
+	sigil   bool   // запись вида @name(params): параметры в скобках после имени
+}
+
+// tagSyntaxes — поддерживаемые записи тегов
+var tagSyntaxes = map[string]tagSyntax{
+	"parens":   {pattern: additional_functions.TagPattern, open: "(", close: ")"},             // (up, 2)
+	"braces":   {pattern: `\{\{\s*[a-zA-Z]+\s*(?:,[^{}\n]*)?\}\}`, open: "{{", close: "}}"},   // {{up, 2}}
+	"brackets": {pattern: `\[\[\s*[a-zA-Z]+\s*(?:,[^\[\]\n]*)?\]\]`, open: "[[", close: "]]"}, // [[up, 2]]
+	"at":       {pattern: `@[a-zA-Z]+(?:\([^()\n]*\))?`, open: "@", sigil: true},              // @up(2), @hex
+}
+
+// currentTagSyntax — выбранная запись тегов и её шаблон для проверки целого токена
+var (
+	currentTagSyntax = tagSyntaxes["parens"]
+	tagToken         = anchored(currentTagSyntax.pattern)
+)
+
+// anchored компилирует шаблон, совпадающий только с целой строкой
+func anchored(pattern string) *regexp.Regexp {
+	return regexp.MustCompile(`^(?:` + pattern + `)$`)
+}
+
+// SetTagSyntax задаёт запись тегов: "parens" — (up, 2), "braces" — {{up, 2}}, "brackets" — [[up, 2]]
+// или "at" — @up(2). При записи, отличной от круглых скобок, текст в скобках никогда не считается тегом.
+func SetTagSyntax(name string) error {
+	syntax, ok := tagSyntaxes[strings.ToLower(name)]
+	if !ok {
+		return fmt.Errorf("неизвестная запись тегов: %s", name)
+	}
+	currentTagSyntax = syntax
+	tagToken = anchored(syntax.pattern)
+	tokenRegexp = buildTokenRegexp()
+	return nil
+}
+
+// splitTag разбирает тег в выбранной записи на имя (в нижнем регистре) и параметры
+func splitTag(token string) (string, []string) {
+	content := strings.TrimPrefix(token, currentTagSyntax.open)
+	if currentTagSyntax.sigil {
+		// @up(2, tr) → up, [2, tr]
+		name, params, _ := strings.Cut(strings.TrimSuffix(content, ")"), "(")
+		parts := []string{}
+		if strings.TrimSpace(params) != "" {
+			parts = strings.Split(params, ",")
+		}
+		return strings.ToLower(strings.TrimSpace(name)), parts
+	}
+	parts := strings.Split(strings.TrimSuffix(content, currentTagSyntax.close), ",")
+	return strings.ToLower(strings.TrimSpace(parts[0])), parts[1:]
+}
+
+// tagTokens превращает тег из текста в токены: известный тег — во внутреннюю запись (name, params),
+// неизвестный — в обычный текст: скобки отдельно, содержимое разбивается на токены, как и остальной текст
+func tagTokens(token string) []string {
+	name, params := splitTag(token)
+	if isKnownTag(name) {
+		return []string{"(" + strings.Join(append([]string{name}, params...), ",") + ")"}
+	}
+	if currentTagSyntax.sigil {
+		return []string{token}
+	}
+
+	result := strings.Split(currentTagSyntax.open, "")
+	result = append(result, tokenize(token[len(currentTagSyntax.open):len(token)-len(currentTagSyntax.close)])...)
+	return append(result, strings.Split(currentTagSyntax.close, "")...)
+}
@@ -1,6 +1,7 @@
 package text_processing
 
 import (
+	"fmt"
 	"go_reloaded/additional_functions"
 	"math/big"
 	"strconv"
@@ -15,6 +16,221 @@ type Transform struct {
 	count int
 }
 
+// pendingGroup — трансформация, которой нужны сразу несколько слов. Пока она собирает слова,
+// обычные трансформации к ним не применяются.
+type pendingGroup struct {
+	fn     func(words []string) []string
+	count  int
+	merge  bool     // сливает ли трансформация слова в один токен (тогда сбор прерывается на пунктуации)
+	tokens []string // собранные токены в обратном порядке
+	tag    string   // исходный тег для диагностики
+	wanted int      // сколько слов запрошено в теге
+}
+
+// tagOptions — необязательные параметры тега, указанные после количества слов: (tag, count, option)
+type tagOptions struct {
+	locale string // язык оформления, например "en", "ru", "tr" или "de"
+	group  bool   // разбивать ли число на разряды
+	style  string // руководство по стилю для (title): "ap", "chicago" или "apa"
+	smart  bool   // умная капитализация для (cap): аббревиатуры, имена и словарь
+}
+
+// Виды параметров, которые может принимать тег
+const (
+	optLocale = 1 << iota // язык оформления: (up, tr)
+	optGroup              // разбивка на разряды: (hex, group)
+	optStyle              // руководство по стилю: (title, ap)
+	optSmart              // умная капитализация: (cap, smart)
+)
+
+// tagHandler строит функцию трансформации слова с учётом параметров тега
+type tagHandler func(opts tagOptions) func(string) string
+
+// tagSpec — тег: построитель трансформации и параметры, которые он принимает
+type tagSpec struct {
+	handler tagHandler
+	accepts int
+}
+
+// simpleTag оборачивает трансформацию, не зависящую от параметров тега
+func simpleTag(fn func(string) string) tagSpec {
+	return tagSpec{handler: func(tagOptions) func(string) string {
+		return fn
+	}}
+}
+
+// numericTag оборачивает числовое преобразование: при параметре group или указанном языке
+// результат разбивается на разряды
+func numericTag(fn func(string) string) tagSpec {
+	return tagSpec{accepts: optGroup | optLocale, handler: func(opts tagOptions) func(string) string {
+		if !opts.group && opts.locale == "" {
+			return fn
+		}
+		return func(s string) string {
+			converted := fn(s)
+			if !additional_functions.IsInteger(converted) {
+				return converted
+			}
+			return groupDigits(converted, opts.locale)
+		}
+	}}
+}
+
+// tagRegistry — набор поддерживаемых тегов
+var tagRegistry = map[string]tagSpec{
+	// Регистр меняется по правилам языка тега или документа: (up, 2, tr)
+	"up": {accepts: optLocale, handler: func(opts tagOptions) func(string) string {
+		return rulesFor(opts.locale).upper
+	}},
+	"low": {accepts: optLocale, handler: func(opts tagOptions) func(string) string {
+		return rulesFor(opts.locale).lower
+	}},
+	"cap": {accepts: optLocale | optSmart, handler: func(opts tagOptions) func(string) string {
+		// Умная капитализация (cap, smart) учитывает аббревиатуры, имена и словарь
+		if opts.smart {
+			rules := rulesFor(opts.locale)
+			return func(s string) string { return smartCapitalize(s, rules) }
+		}
+		if opts.locale != "" || documentLocale != defaultLocale {
+			rules := rulesFor(opts.locale)
+			return func(s string) string { return localeCapitalize(s, rules) }
+		}
+		return capitalize
+	}},
+	"hex": numericTag(func(s string) string {
+		// Преобразование из HEX в десятичное, если строка — валидный hex
+		if additional_functions.IsHex(s) {
+			n := new(big.Int)
+			if _, success := n.SetString(s, 16); success {
+				return n.String()
+			}
+		}
+		return s
+	}),
+	"bin": numericTag(func(s string) string {
+		// Преобразование из BIN в десятичное, если строка — валидный бинарный
+		if additional_functions.IsBinary(s) {
+			n := new(big.Int)
+			if _, success := n.SetString(s, 2); success {
+				return n.String()
+			}
+		}
+		return s
+	}),
+	"roman":  simpleTag(toRoman),
+	"arabic": numericTag(fromRoman),
+	"ord":    simpleTag(toOrdinal),
+	"group": {accepts: optLocale, handler: func(opts tagOptions) func(string) string {
+		return func(s string) string {
+			if !additional_functions.IsInteger(s) {
+				addDiagnostic("group", s, "не является целым числом")
+				return s
+			}
+			return groupDigits(s, opts.locale)
+		}
+	}},
+}
+
+// groupSpec — тег, которому нужны сразу несколько слов: построитель трансформации,
+// принимаемые параметры и то, сливает ли он слова в один токен
+type groupSpec struct {
+	handler func(opts tagOptions) func(words []string) []string
+	accepts int
+	merge   bool
+}
+
+// mergeTag оборачивает объединяющую трансформацию: count слов сливаются в один токен
+func mergeTag(fn func(words []string) string) groupSpec {
+	return groupSpec{merge: true, handler: func(tagOptions) func(words []string) []string {
+		return func(words []string) []string { return []string{fn(words)} }
+	}}
+}
+
+// groupRegistry — теги, работающие с несколькими словами сразу
+var groupRegistry = map[string]groupSpec{
+	// Стили именования: (snake, 3) превращает "user account id" в "user_account_id"
+	"snake":    mergeTag(snakeCase),
+	"kebab":    mergeTag(kebabCase),
+	"constant": mergeTag(constantCase),
+	"camel":    mergeTag(camelCase),
+	"pascal":   mergeTag(pascalCase),
+	// Заголовок по правилам ap, chicago или apa: (title, 3, ap)
+	"title": {accepts: optStyle, handler: func(opts tagOptions) func(words []string) []string {
+		return func(words []string) []string { return titleCase(words, opts.style) }
+	}},
+}
+
+// capitalize делает первую букву слова заглавной, а остальные — строчными
+func capitalize(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	// Обработка слова, начинающегося с кавычки
+	if strings.HasPrefix(s, "'") || strings.HasPrefix(s, "\"") {
+		trimmed := s[1:]
+		r, size := utf8.DecodeRuneInString(trimmed)
+		return string(s[0]) + string(unicode.ToUpper(r)) + strings.ToLower(trimmed[size:])
+	}
+
+	// Обычная капитализация: первая буква заглавная, остальные строчные
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + strings.ToLower(s[size:])
+}
+
+// parseTagOptions разбирает параметры тега после его имени: количество слов и опции.
+// accepts — виды опций, которые принимает тег. Возвращает false, если количество некорректно
+// или указано дважды, опция неизвестна или не подходит этому тегу.
+func parseTagOptions(token string, params []string, accepts int) (int, tagOptions, bool) {
+	count := 1
+	counted := false
+	opts := tagOptions{}
+	for _, param := range params {
+		param = strings.ToLower(strings.TrimSpace(param))
+		if parsedCount, err := strconv.Atoi(param); err == nil {
+			if parsedCount <= 0 {
+				return 0, opts, false
+			}
+			if counted {
+				addDiagnostic("tags", token, "количество слов указано дважды: "+param)
+				return 0, opts, false
+			}
+			count, counted = parsedCount, true
+			continue
+		}
+
+		switch {
+		case param == "group" && accepts&optGroup != 0:
+			opts.group = true
+		case param == "smart" && accepts&optSmart != 0:
+			opts.smart = true
+		case isKnownTitleStyle(param) && accepts&optStyle != 0:
+			opts.style = param
+		case isKnownLocale(param) && accepts&optLocale != 0:
+			opts.locale = param
+		default:
+			addDiagnostic("tags", token, "неизвестный параметр тега: "+param)
+			return 0, opts, false
+		}
+	}
+	return count, opts, true
+}
+
+// tagName возвращает имя тега в нижнем регистре: (Up, 2) → up
+func tagName(token string) string {
+	content := strings.Trim(token, "()")
+	return strings.ToLower(strings.TrimSpace(strings.Split(content, ",")[0]))
+}
+
+// isKnownTag проверяет, есть ли тег с таким именем; остальной текст в скобках считается обычным текстом
+func isKnownTag(name string) bool {
+	if _, ok := tagRegistry[name]; ok {
+		return true
+	}
+	_, ok := groupRegistry[name]
+	return ok
+}
+
 // reverseSlice переворачивает срез строк — используется для обработки тэгов справа налево
 func reverseSlice(slice []string) []string {
 	reversed := make([]string, len(slice))
@@ -24,8 +240,10 @@ func reverseSlice(slice []string) []string {
 	return reversed
 }
 
-// ProcessTags применяет трансформации по тегам: (up), (low), (cap), (hex), (bin)
-// Теги могут иметь форму (tag,count) — например: (up,3)
+// ProcessTags применяет трансформации по тегам: (up), (low), (cap), (hex), (bin), (roman), (arabic), (ord), (group)
+// Теги могут иметь форму (tag,count) — например: (up,3), а также параметры: (hex, group), (group, 2, ru)
+// Объединяющие теги (snake), (camel), (pascal), (kebab), (constant) сливают count слов в один токен,
+// а (title, count, style) оформляет count слов как заголовок по правилам ap, chicago или apa
 func ProcessTags(tokens []string) []string {
 	// Переворачиваем токены для обратной обработки
 	reversed := reverseSlice(tokens)
@@ -34,107 +252,143 @@ func ProcessTags(tokens []string) []string {
 
 	// Проверка: является ли токен тегом вида (xxx)
 	isTag := func(token string) bool {
-		return strings.HasPrefix(token, "(") && strings.HasSuffix(token, ")")
+		return len(token) > 1 && strings.HasPrefix(token, "(") && strings.HasSuffix(token, ")")
 	}
 
-	// Проверка: является ли токен словом (не тег и не пунктуация)
+	// Проверка: является ли токен словом (не тег, не пунктуация, не кавычка, не тире и не скобка)
 	isWord := func(token string) bool {
-		return !isTag(token) && !additional_functions.IsPunctuation(token)
+		return !isTag(token) && !additional_functions.IsPunctuation(token) && !additional_functions.IsQuote(token) &&
+			!additional_functions.IsDash(token) && !additional_functions.IsBracket(token)
 	}
 
-	// Набор поддерживаемых трансформаций
-	transformations := map[string]func(string) string{
-		"up":  strings.ToUpper,
-		"low": strings.ToLower,
-		"cap": func(s string) string {
-			if s == "" {
-				return ""
-			}
-
-			// Обработка слова, начинающегося с кавычки
-			if strings.HasPrefix(s, "'") || strings.HasPrefix(s, "\"") {
-				trimmed := s[1:]
-				r, size := utf8.DecodeRuneInString(trimmed)
-				return string(s[0]) + string(unicode.ToUpper(r)) + strings.ToLower(trimmed[size:])
-			}
-
-			// Обычная капитализация: первая буква заглавная, остальные строчные
-			r, size := utf8.DecodeRuneInString(s)
-			return string(unicode.ToUpper(r)) + strings.ToLower(s[size:])
-		},
-		"hex": func(s string) string {
-			// Преобразование из HEX в десятичное, если строка — валидный hex
-			if additional_functions.IsHex(s) {
-				n := new(big.Int)
-				if _, success := n.SetString(s, 16); success {
-					return n.String()
+	// emit применяет к токену активные трансформации и добавляет его в результат
+	emit := func(token string) {
+		// Если это слово, применяем активные трансформации
+		if isWord(token) && len(activeTransforms) > 0 {
+			for i := len(activeTransforms) - 1; i >= 0; i-- {
+				if activeTransforms[i].count > 0 {
+					token = activeTransforms[i].fn(token)
+					activeTransforms[i].count--
 				}
 			}
-			return s
-		},
-		"bin": func(s string) string {
-			// Преобразование из BIN в десятичное, если строка — валидный бинарный
-			if additional_functions.IsBinary(s) {
-				n := new(big.Int)
-				if _, success := n.SetString(s, 2); success {
-					return n.String()
-				}
+		}
+		transformed = append(transformed, token)
+
+		// Убираем трансформации, у которых счётчик = 0
+		var newActive []Transform
+		for _, t := range activeTransforms {
+			if t.count > 0 {
+				newActive = append(newActive, t)
+			}
+		}
+		activeTransforms = newActive
+	}
+
+	// Групповая трансформация собирает слова, а затем отдаёт результат в emit;
+	// результат объединяющего тега считается одним словом
+	var group *pendingGroup
+	flushGroup := func() {
+		if group == nil {
+			return
+		}
+		fn, tokens := group.fn, group.tokens
+		// Сбор прервался раньше: тег, другой токен или начало текста
+		if group.count > 0 {
+			addDiagnostic("tags", group.tag, fmt.Sprintf("тег применён к %d из %d слов", group.wanted-group.count, group.wanted))
+		}
+		group = nil
+
+		// Слова передаются в трансформацию в исходном порядке
+		words := []string{}
+		for i := len(tokens) - 1; i >= 0; i-- {
+			if isWord(tokens[i]) {
+				words = append(words, tokens[i])
+			}
+		}
+		if len(words) == 0 {
+			for _, token := range tokens {
+				emit(token)
+			}
+			return
+		}
+
+		result := fn(words)
+		if len(result) != len(words) {
+			// Слова слились: выдаём результат вместо собранных токенов
+			for i := len(result) - 1; i >= 0; i-- {
+				emit(result[i])
+			}
+			return
+		}
+
+		// Возвращаем преобразованные слова на свои места
+		wordIndex := len(result) - 1
+		for _, token := range tokens {
+			if isWord(token) {
+				token = result[wordIndex]
+				wordIndex--
 			}
-			return s
-		},
+			emit(token)
+		}
 	}
 
 	// Основной цикл обработки токенов
 	for _, token := range reversed {
+		// Пока групповая трансформация не набрала нужное число слов, собираем их
+		if group != nil && token != "\n" {
+			isPunct := additional_functions.IsPunctuation(token) || additional_functions.IsBracket(token)
+			if isWord(token) || (isPunct && !group.merge) {
+				group.tokens = append(group.tokens, token)
+				if !isPunct {
+					group.count--
+				}
+				if group.count == 0 {
+					flushGroup()
+				}
+				continue
+			}
+		}
+		flushGroup()
+
 		if isTag(token) {
 			// Разбираем тег и его параметры
-			tagContent := token[1 : len(token)-1]
-			parts := strings.Split(tagContent, ",")
-			transformation := strings.ToLower(strings.TrimSpace(parts[0]))
+			parts := strings.Split(token[1:len(token)-1], ",")
+			transformation := tagName(token)
+
+			// Групповые теги: (snake), (camel), (pascal), (kebab), (constant), (title, count, style)
+			if spec, ok := groupRegistry[transformation]; ok {
+				if count, opts, ok := parseTagOptions(token, parts[1:], spec.accepts); ok {
+					group = &pendingGroup{
+						fn:     spec.handler(opts),
+						count:  count,
+						merge:  spec.merge,
+						tag:    token,
+						wanted: count,
+					}
+				}
+				continue
+			}
 
 			// Если тег неизвестен — сохраняем как есть
-			if _, ok := transformations[transformation]; !ok {
+			spec, ok := tagRegistry[transformation]
+			if !ok {
 				transformed = append(transformed, token)
 				continue
 			}
 
-			// Обработка второго параметра (кол-во слов)
-			count := 1
-			if len(parts) > 1 {
-				countStr := strings.TrimSpace(parts[1])
-				parsedCount, err := strconv.Atoi(countStr)
-				if err != nil || parsedCount <= 0 {
-					continue // пропускаем тег с некорректным числом
-				}
-				count = parsedCount
+			// Обработка параметров (кол-во слов и опции)
+			count, opts, ok := parseTagOptions(token, parts[1:], spec.accepts)
+			if !ok {
+				continue // пропускаем тег с некорректными параметрами
 			}
 
 			// Добавляем трансформацию в стек активных
-			if transformFn, ok := transformations[transformation]; ok {
-				activeTransforms = append(activeTransforms, Transform{fn: transformFn, count: count})
-			}
+			activeTransforms = append(activeTransforms, Transform{fn: spec.handler(opts), count: count})
 		} else {
-			// Если это слово, применяем активные трансформации
-			if isWord(token) && len(activeTransforms) > 0 {
-				for i := len(activeTransforms) - 1; i >= 0; i-- {
-					if activeTransforms[i].count > 0 {
-						token = activeTransforms[i].fn(token)
-						activeTransforms[i].count--
-					}
-				}
-			}
-			transformed = append(transformed, token)
-
-			// Убираем трансформации, у которых счётчик = 0
-			var newActive []Transform
-			for _, t := range activeTransforms {
-				if t.count > 0 {
-					newActive = append(newActive, t)
-				}
-			}
-			activeTransforms = newActive
+			emit(token)
 		}
 	}
+	flushGroup()
 
 	// Возвращаем в исходном порядке
 	return reverseSlice(transformed)
@@ -0,0 +1,90 @@
+package text_processing
+
+import (
+	"strings"
+	"unicode"
+)
+
+// defaultTitleStyle — руководство по стилю, используемое тегом (title) по умолчанию
+const defaultTitleStyle = "chicago"
+
+// shortMinorWords — артикли, короткие союзы и предлоги (до трёх букв),
+// которые AP и APA оставляют строчными
+var shortMinorWords = []string{
+	"a", "an", "the",
+	"and", "but", "for", "nor", "or", "so", "yet",
+	"as", "at", "by", "in", "of", "off", "on", "per", "to", "up", "via",
+}
+
+// titleMinorWords — слова, остающиеся строчными внутри заголовка, для каждого руководства по стилю.
+// Chicago оставляет строчными все предлоги независимо от длины.
+var titleMinorWords = map[string]map[string]bool{
+	"ap":  wordSet(shortMinorWords),
+	"apa": wordSet(shortMinorWords),
+	"chicago": wordSet([]string{
+		"a", "an", "the",
+		"and", "but", "for", "nor", "or",
+		"about", "above", "across", "after", "against", "along", "among", "around", "as", "at",
+		"before", "behind", "below", "beneath", "beside", "between", "beyond", "by",
+		"down", "during", "except", "from", "in", "inside", "into", "like", "near",
+		"of", "off", "on", "onto", "out", "outside", "over", "past", "per", "since",
+		"through", "throughout", "to", "toward", "towards", "under", "underneath", "until",
+		"up", "upon", "via", "with", "within", "without",
+	}),
+}
+
+// wordSet строит множество из списка слов
+func wordSet(words []string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}
+
+// isKnownTitleStyle проверяет, поддерживается ли руководство по стилю
+func isKnownTitleStyle(style string) bool {
+	_, ok := titleMinorWords[style]
+	return ok
+}
+
+// titleCase оформляет слова как заголовок: служебные слова остаются строчными,
+// кроме первого и последнего слова; части слов через дефис обрабатываются отдельно.
+func titleCase(words []string, style string) []string {
+	minor, ok := titleMinorWords[style]
+	if !ok {
+		minor = titleMinorWords[defaultTitleStyle]
+	}
+
+	result := make([]string, len(words))
+	for i, word := range words {
+		parts := strings.Split(word, "-")
+		for j, part := range parts {
+			// Первая часть первого слова и последняя часть последнего слова всегда с заглавной
+			edge := (i == 0 && j == 0) || (i == len(words)-1 && j == len(parts)-1)
+			core := strings.ToLower(strings.TrimFunc(part, func(r rune) bool {
+				return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+			}))
+			if !edge && minor[core] {
+				parts[j] = strings.ToLower(part)
+			} else {
+				parts[j] = upperFirstLetter(part)
+			}
+		}
+		result[i] = strings.Join(parts, "-")
+	}
+	return result
+}
+
+// upperFirstLetter делает заглавной первую букву слова, пропуская ведущие кавычки и знаки,
+// остальные буквы не меняются
+func upperFirstLetter(s string) string {
+	runes := []rune(s)
+	for i, r := range runes {
+		if unicode.IsLetter(r) {
+			runes[i] = unicode.ToUpper(r)
+			break
+		}
+	}
+	return string(runes)
+}